@@ -0,0 +1,670 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// BindJSON decodes the JSON read from the given reader into the given target (which must be a
+// non-nil pointer), then validates it using the given constraints, see JSONBinder.
+func BindJSON(r io.Reader, target any, constraints ...Constraint) ([]ConstraintViolation, error) {
+	return JSONBinder{}.Bind(context.Background(), r, target, constraints...)
+}
+
+// JSONBinder decodes JSON into a target value, then validates it, reporting any problems with the
+// JSON itself as violations, alongside those of the constraints. This means that a value of the
+// wrong type (e.g. `"age": "ten"`), or an unknown field, is reported like any other violation,
+// under the path of the value, instead of as a single opaque decoding error.
+//
+// The whole JSON document is checked against the type of the target, so that every such problem is
+// reported, not just the first. Paths use the same names as they would when validating the target
+// (see FieldName), and constraint violations of values that couldn't be decoded, or that are
+// beneath them, are left out, as they'd only be noise.
+type JSONBinder struct {
+	// Options are used to configure the Context used to validate the target, and to produce
+	// violations for any problems with the JSON (e.g. the StructTag, and PathFormatter).
+	Options []Option
+	// AllowUnknownFields stops object members that don't match any field of a struct from being
+	// reported as violations.
+	AllowUnknownFields bool
+}
+
+// Bind decodes the JSON read from the given reader into the given target (which must be a non-nil
+// pointer), then validates it using the given constraints. Violations are returned for any problems
+// with the JSON, and any violations of the constraints. If the JSON isn't syntactically valid, only
+// a single violation with the CodeInvalidJSON code is returned, and the constraints aren't applied.
+// An error is returned if reading fails, or if the given context.Context is cancelled.
+func (b JSONBinder) Bind(ctx context.Context, r io.Reader, target any, constraints ...Constraint) ([]ConstraintViolation, error) {
+	rval := reflect.ValueOf(target)
+	if rval.Kind() != reflect.Pointer || rval.IsNil() {
+		panic("validation: target given to JSONBinder must be a non-nil pointer")
+	}
+
+	vctx := NewContext(target, b.Options...).WithContext(ctx)
+
+	data, document, violation, err := readJSON(vctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if violation != nil {
+		return TranslateViolations(vctx.Translator, []ConstraintViolation{*violation}), nil
+	}
+
+	decodeViolations := b.check(vctx, document, rval.Type().Elem())
+
+	// Values that don't match the type of the target are skipped, and any valid values are still
+	// decoded, so that constraints can be applied to them. The first mismatch is returned as an
+	// error, but we've already found them all.
+	if err := json.Unmarshal(data, target); err != nil && len(decodeViolations) == 0 {
+		decodeViolations = append(decodeViolations, vctx.TemplateViolation(CodeInvalidJSON, "value is not valid JSON: {error}", map[string]any{
+			"error": err.Error(),
+		}))
+	}
+
+	violations, err := validate(vctx, constraints...)
+	if err != nil {
+		return nil, err
+	}
+
+	violations = slices.DeleteFunc(violations, func(violation ConstraintViolation) bool {
+		return shadowedBy(violation, decodeViolations)
+	})
+
+	decodeViolations = TranslateViolations(vctx.Translator, decodeViolations)
+	violations = append(decodeViolations, violations...)
+
+	if !vctx.PreserveOrder {
+		sortViolations(violations)
+	}
+
+	return vctx.limit(violations), nil
+}
+
+// readJSON reads a single JSON value from the given reader, returning its raw bytes, and its
+// generic representation (with numbers as json.Number). If it's not valid JSON, a violation is
+// returned instead. An error is only returned if reading fails.
+func readJSON(ctx Context, r io.Reader) ([]byte, any, *ConstraintViolation, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var document any
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	err = dec.Decode(&document)
+	switch {
+	case errors.Is(err, io.EOF):
+		err = io.ErrUnexpectedEOF
+	case err == nil && dec.More():
+		err = errors.New("invalid character after top-level value")
+	}
+
+	if err != nil {
+		details := map[string]any{
+			"error": err.Error(),
+		}
+
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			details["offset"] = syntaxErr.Offset
+		}
+
+		violation := ctx.TemplateViolation(CodeInvalidJSON, "value is not valid JSON: {error}", details)
+		return nil, nil, &violation, nil
+	}
+
+	return data, document, nil, nil
+}
+
+// check returns violations for any parts of the given JSON value that can't be decoded into a value
+// of the given type, i.e. values of the wrong type, and unknown fields.
+func (b JSONBinder) check(ctx Context, value any, typ reflect.Type) []ConstraintViolation {
+	if value == nil {
+		// Null is allowed anywhere, it leaves the value untouched.
+		return nil
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		if implementsUnmarshaler(typ) {
+			break
+		}
+
+		typ = typ.Elem()
+	}
+
+	if implementsUnmarshaler(typ) || implementsUnmarshaler(reflect.PointerTo(typ)) {
+		return checkUnmarshaler(ctx, value, typ, UnwrapType(typ).String())
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return typeViolations(ctx, value, "boolean", nil)
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return typeViolations(ctx, value, "string", nil)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return checkNumber(ctx, value, "integer", typ, func(n json.Number) error {
+			_, err := strconv.ParseInt(n.String(), 10, typ.Bits())
+			return err
+		})
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return checkNumber(ctx, value, "non-negative integer", typ, func(n json.Number) error {
+			_, err := strconv.ParseUint(n.String(), 10, typ.Bits())
+			return err
+		})
+	case reflect.Float32, reflect.Float64:
+		return checkNumber(ctx, value, "number", typ, func(n json.Number) error {
+			_, err := strconv.ParseFloat(n.String(), typ.Bits())
+			return err
+		})
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings.
+			return checkUnmarshaler(ctx, value, typ, "base64 string")
+		}
+
+		return b.checkArray(ctx, value, typ)
+	case reflect.Array:
+		return b.checkArray(ctx, value, typ)
+	case reflect.Map:
+		return b.checkMap(ctx, value, typ)
+	case reflect.Struct:
+		return b.checkStruct(ctx, value, typ)
+	}
+
+	return nil
+}
+
+// checkArray checks the given JSON value against the given array or slice type, see check.
+func (b JSONBinder) checkArray(ctx Context, value any, typ reflect.Type) []ConstraintViolation {
+	elements, ok := value.([]any)
+	if !ok {
+		return typeViolations(ctx, value, "array", nil)
+	}
+
+	var violations []ConstraintViolation
+	for i, element := range elements {
+		if typ.Kind() == reflect.Array && i >= typ.Len() {
+			// Extra elements are discarded when decoding into an array.
+			break
+		}
+
+		violations = append(violations, b.check(ctx.WithIndex(i, reflect.ValueOf(element)), element, typ.Elem())...)
+	}
+
+	return violations
+}
+
+// checkMap checks the given JSON value against the given map type, see check.
+func (b JSONBinder) checkMap(ctx Context, value any, typ reflect.Type) []ConstraintViolation {
+	members, ok := value.(map[string]any)
+	if !ok {
+		return typeViolations(ctx, value, "object", nil)
+	}
+
+	keys := make([]string, 0, len(members))
+	for key := range members {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, naturalCompare)
+
+	var violations []ConstraintViolation
+	for _, key := range keys {
+		keyVal, ok := mapKey(key, typ.Key())
+		if !ok {
+			keyCtx := ctx.WithKey(reflect.ValueOf(key), reflect.ValueOf(key)).WithPathKind(PathKindKey)
+			violations = append(violations, typeViolations(keyCtx, key, keyTypeName(typ.Key()), nil)...)
+
+			continue
+		}
+
+		member := members[key]
+		violations = append(violations, b.check(ctx.WithKey(reflect.ValueOf(keyVal), reflect.ValueOf(member)), member, typ.Elem())...)
+	}
+
+	return violations
+}
+
+// checkStruct checks the given JSON value against the given struct type, see check.
+func (b JSONBinder) checkStruct(ctx Context, value any, typ reflect.Type) []ConstraintViolation {
+	members, ok := value.(map[string]any)
+	if !ok {
+		return typeViolations(ctx, value, "object", nil)
+	}
+
+	fields := cachedJSONFields(typ)
+
+	keys := make([]string, 0, len(members))
+	for key := range members {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, naturalCompare)
+
+	var violations []ConstraintViolation
+	for _, key := range keys {
+		member := members[key]
+
+		fi := fields.lookup(key)
+		if fi == nil {
+			if b.AllowUnknownFields {
+				continue
+			}
+
+			fieldCtx := ctx.WithField(key, key, reflect.ValueOf(member))
+			violations = append(violations, fieldCtx.TemplateViolation(CodeUnknownField, "unknown field", nil))
+
+			continue
+		}
+
+		if _, opts, _ := strings.Cut(fi.field.Tag.Get("json"), ","); slices.Contains(strings.Split(opts, ","), "string") {
+			// Values of fields with the string option are quoted, encoding/json checks these itself.
+			continue
+		}
+
+		fieldCtx := ctx.WithField(fi.field.Name, fi.name(ctx.StructTag), reflect.ValueOf(member))
+		violations = append(violations, b.check(fieldCtx, member, fi.field.Type)...)
+	}
+
+	return violations
+}
+
+// checkNumber checks that the given JSON value is a number that can be parsed by the given function
+// as a value of the given type, see check.
+func checkNumber(ctx Context, value any, expected string, typ reflect.Type, parse func(json.Number) error) []ConstraintViolation {
+	n, ok := value.(json.Number)
+	if !ok {
+		return typeViolations(ctx, value, expected, nil)
+	}
+
+	if err := parse(n); err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) && errors.Is(numErr.Err, strconv.ErrRange) {
+			// The number is of the right type, but is too large (or small) for the type it's decoded
+			// into, so the size of that type is the type that's expected.
+			expected = fmt.Sprintf("%d-bit %s", typ.Bits(), expected)
+		}
+
+		return typeViolations(ctx, value, expected, nil)
+	}
+
+	return nil
+}
+
+// checkUnmarshaler checks the given JSON value against the given type by decoding it into a new
+// value of that type using encoding/json, e.g. for types that decode themselves, see check.
+func checkUnmarshaler(ctx Context, value any, typ reflect.Type, expected string) []ConstraintViolation {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, reflect.New(typ).Interface()); err != nil {
+		return typeViolations(ctx, value, expected, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	return nil
+}
+
+// typeViolations returns a violation for the given JSON value not being of the expected type, with
+// the given additional details.
+func typeViolations(ctx Context, value any, expected string, details map[string]any) []ConstraintViolation {
+	merged := map[string]any{
+		"expected": expected,
+		"actual":   jsonValueType(value),
+	}
+
+	maps.Copy(merged, details)
+
+	return []ConstraintViolation{
+		ctx.TemplateViolation(CodeInvalidType, "value must be of type {expected}", merged),
+	}
+}
+
+// jsonValueType returns the name of the type of the given JSON value.
+func jsonValueType(value any) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+
+	return "null"
+}
+
+// keyTypeName returns the name of the type that map keys of the given type are expected to be, when
+// decoded from the names of object members.
+func keyTypeName(typ reflect.Type) string {
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return typ.String()
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "non-negative integer"
+	}
+
+	return "string"
+}
+
+// mapKey returns the given object member name as a value of the given map key type, as it'd be
+// decoded by encoding/json, and true, or false if it can't be.
+func mapKey(key string, typ reflect.Type) (any, bool) {
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		ptr := reflect.New(typ)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(key)); err != nil {
+			return nil, false
+		}
+
+		return ptr.Elem().Interface(), true
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(key, 10, typ.Bits())
+		if err != nil {
+			return nil, false
+		}
+
+		return reflect.ValueOf(n).Convert(typ).Interface(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(key, 10, typ.Bits())
+		if err != nil {
+			return nil, false
+		}
+
+		return reflect.ValueOf(n).Convert(typ).Interface(), true
+	}
+
+	return reflect.ValueOf(key).Convert(typ).Interface(), true
+}
+
+// shadowedBy returns true if the given violation is of a value that is, or is beneath, a value that
+// has one of the given decoding violations.
+func shadowedBy(violation ConstraintViolation, decodeViolations []ConstraintViolation) bool {
+	for _, decodeViolation := range decodeViolations {
+		if decodeViolation.Code == CodeUnknownField || len(decodeViolation.Segments) > len(violation.Segments) {
+			continue
+		}
+
+		shadowed := true
+		for i, segment := range decodeViolation.Segments {
+			other := violation.Segments[i]
+			if segment.Kind != other.Kind || segment.String() != other.String() {
+				shadowed = false
+				break
+			}
+		}
+
+		if shadowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// implementsUnmarshaler returns true if values of the given type decode themselves from JSON.
+func implementsUnmarshaler(typ reflect.Type) bool {
+	return typ.Implements(jsonUnmarshalerType) || typ.Implements(textUnmarshalerType)
+}
+
+// Types of the interfaces used by encoding/json to let values decode themselves.
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// jsonFieldsCache holds a *jsonFields for each struct type that has been bound, keyed by its
+// reflect.Type.
+var jsonFieldsCache sync.Map
+
+// jsonFields holds the fields of a struct type that JSON object members are decoded into, keyed by
+// their JSON names.
+type jsonFields struct {
+	byName map[string]*fieldInfo
+	names  []string
+}
+
+// cachedJSONFields returns the jsonFields for the given struct type, building and caching them if
+// they haven't been seen before.
+func cachedJSONFields(typ reflect.Type) *jsonFields {
+	if fields, ok := jsonFieldsCache.Load(typ); ok {
+		return fields.(*jsonFields)
+	}
+
+	found := jsonTypeFields(typ)
+
+	fields := &jsonFields{
+		byName: make(map[string]*fieldInfo, len(found)),
+		names:  make([]string, 0, len(found)),
+	}
+
+	for _, f := range found {
+		field := typ.FieldByIndex(f.index)
+
+		fields.byName[f.name] = &fieldInfo{
+			field:       field,
+			defaultName: structFieldName(field, DefaultNameStructTag),
+		}
+
+		fields.names = append(fields.names, f.name)
+	}
+
+	actual, _ := jsonFieldsCache.LoadOrStore(typ, fields)
+	return actual.(*jsonFields)
+}
+
+// lookup returns the field that the object member with the given name is decoded into, or nil if
+// there isn't one. Like encoding/json, an exact match is preferred, but names are matched without
+// regard to case.
+func (f *jsonFields) lookup(name string) *fieldInfo {
+	if fi, ok := f.byName[name]; ok {
+		return fi
+	}
+
+	for _, fieldName := range f.names {
+		if strings.EqualFold(fieldName, name) {
+			return f.byName[fieldName]
+		}
+	}
+
+	return nil
+}
+
+// jsonTypeField is a field of a struct type that JSON object members are decoded into, see
+// jsonTypeFields.
+type jsonTypeField struct {
+	name   string
+	tagged bool
+	index  []int
+}
+
+// jsonTypeFields returns the fields of the given struct type that JSON object members are decoded
+// into, in index order. This follows exactly the same rules as encoding/json, which differ from
+// Go's rules for embedded fields: the fields of untagged embedded structs are promoted, even if
+// the struct type is unexported, and fields with a JSON name take precedence over those without
+// one at the same depth. If there's still more than one field with the same name at the shallowest
+// depth it's found at, none of them are decoded.
+func jsonTypeFields(typ reflect.Type) []jsonTypeField {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var fields []jsonTypeField
+
+	// Embedded structs are explored breadth-first, so that shallower fields are found first. Like
+	// encoding/json, each struct type is only explored once, at the shallowest depth it's found at.
+	var current []embedded
+	next := []embedded{{typ: typ}}
+
+	var count map[reflect.Type]int
+	nextCount := map[reflect.Type]int{}
+
+	visited := map[reflect.Type]bool{}
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+
+			visited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+
+				if sf.Anonymous {
+					t := sf.Type
+					if t.Kind() == reflect.Pointer {
+						t = t.Elem()
+					}
+
+					if !sf.IsExported() && t.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+
+				name, _, _ := strings.Cut(tag, ",")
+				if !isValidJSONName(name) {
+					name = ""
+				}
+
+				index := make([]int, len(e.index)+1)
+				copy(index, e.index)
+				index[len(e.index)] = i
+
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+
+				if name != "" || !sf.Anonymous || ft.Kind() != reflect.Struct {
+					field := jsonTypeField{name: name, tagged: name != "", index: index}
+					if field.name == "" {
+						field.name = sf.Name
+					}
+
+					fields = append(fields, field)
+
+					// A struct type embedded more than once at the same depth has its fields added
+					// twice, so that they conflict with each other.
+					if count[e.typ] > 1 {
+						fields = append(fields, field)
+					}
+
+					continue
+				}
+
+				nextCount[ft]++
+				if nextCount[ft] == 1 {
+					next = append(next, embedded{typ: ft, index: index})
+				}
+			}
+		}
+	}
+
+	// Fields are grouped by name, with the dominant field first, i.e. the shallowest, preferring
+	// fields with a JSON name, then the first in index order.
+	slices.SortFunc(fields, func(a, b jsonTypeField) int {
+		if c := strings.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+
+		if c := len(a.index) - len(b.index); c != 0 {
+			return c
+		}
+
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+
+			return 1
+		}
+
+		return slices.Compare(a.index, b.index)
+	})
+
+	dominant := fields[:0]
+	for i := 0; i < len(fields); {
+		n := 1
+		for i+n < len(fields) && fields[i+n].name == fields[i].name {
+			n++
+		}
+
+		first := fields[i]
+		if n == 1 || len(first.index) != len(fields[i+1].index) || first.tagged != fields[i+1].tagged {
+			dominant = append(dominant, first)
+		}
+
+		i += n
+	}
+
+	slices.SortFunc(dominant, func(a, b jsonTypeField) int {
+		return slices.Compare(a.index, b.index)
+	})
+
+	return dominant
+}
+
+// isValidJSONName returns true if the given name from a JSON struct tag is one that encoding/json
+// uses, otherwise it uses the name of the field instead.
+func isValidJSONName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r):
+			// Backslashes and quotes are reserved, but other punctuation is allowed.
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return false
+		}
+	}
+
+	return true
+}
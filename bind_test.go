@@ -0,0 +1,312 @@
+package validation_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindAddress struct {
+	Street string `json:"street" validation:"street"`
+}
+
+type bindEmbedded struct {
+	Nickname string `json:"nickname" validation:"nickname"`
+}
+
+type bindTester struct {
+	bindEmbedded
+
+	Name      string         `json:"name" validation:"name"`
+	Age       int8           `json:"age" validation:"age"`
+	Address   *bindAddress   `json:"address" validation:"address"`
+	Tags      []string       `json:"tags" validation:"tags"`
+	Scores    map[int]int    `json:"scores" validation:"scores"`
+	CreatedAt time.Time      `json:"created_at" validation:"created_at"`
+	Count     int            `json:"count,string" validation:"count"`
+	Extra     map[string]any `json:"extra" validation:"extra"`
+}
+
+func bindConstraints() validation.Constraint {
+	return validation.Fields{
+		"Name": constraints.Required,
+		"Age":  constraints.Required,
+		"Address": validation.Fields{
+			"Street": constraints.Required,
+		},
+	}
+}
+
+func bind(t *testing.T, body string, opts ...validation.Option) (bindTester, []validation.ConstraintViolation) {
+	var target bindTester
+
+	binder := validation.JSONBinder{Options: opts}
+	violations, err := binder.Bind(context.Background(), strings.NewReader(body), &target, bindConstraints())
+	require.NoError(t, err)
+
+	return target, violations
+}
+
+func violationCodes(violations []validation.ConstraintViolation) []string {
+	var result []string
+	for _, violation := range violations {
+		result = append(result, violation.Code)
+	}
+
+	return result
+}
+
+func TestJSONBinder_Bind(t *testing.T) {
+	t.Run("should decode valid JSON and apply the constraints", func(t *testing.T) {
+		target, violations := bind(t, `{"name": "Bob", "age": 30, "nickname": "bobby", "address": {"street": ""}}`)
+
+		assert.Equal(t, "Bob", target.Name)
+		assert.Equal(t, int8(30), target.Age)
+		assert.Equal(t, "bobby", target.Nickname)
+		assert.Equal(t, []string{".address.street"}, deepPaths(violations))
+		assert.Equal(t, []string{constraints.CodeRequired}, violationCodes(violations))
+	})
+
+	t.Run("should return violations for values of the wrong type", func(t *testing.T) {
+		target, violations := bind(t, `{"name": "Bob", "age": "ten", "tags": ["a", 1, "c", true]}`)
+
+		assert.Equal(t, "Bob", target.Name)
+		require.Len(t, violations, 3)
+		assert.Equal(t, ".age", violations[0].Path)
+		assert.Equal(t, validation.CodeInvalidType, violations[0].Code)
+		assert.Equal(t, "value must be of type integer", violations[0].Message)
+		assert.Equal(t, "string", violations[0].Details["actual"])
+		assert.Equal(t, ".tags.[1]", violations[1].Path)
+		assert.Equal(t, ".tags.[3]", violations[2].Path)
+	})
+
+	t.Run("should use output names in paths", func(t *testing.T) {
+		_, violations := bind(t, `{"name": "Bob", "age": 1, "created_at": "yesterday", "nickname": 1}`, func(ctx *validation.Context) {
+			ctx.StructTag = "json"
+		})
+
+		assert.Equal(t, []string{".created_at", ".nickname"}, deepPaths(violations))
+		assert.Equal(t, "time.Time", violations[0].Details["expected"])
+		assert.NotEmpty(t, violations[0].Details["error"])
+	})
+
+	t.Run("should return violations for numbers that are out of range", func(t *testing.T) {
+		_, violations := bind(t, `{"name": "Bob", "age": 300}`)
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".age", violations[0].Path)
+		assert.Equal(t, "value must be of type 8-bit integer", violations[0].Message)
+	})
+
+	t.Run("should return violations for map keys of the wrong type", func(t *testing.T) {
+		_, violations := bind(t, `{"name": "Bob", "age": 1, "scores": {"1": 1, "two": 2, "3": "three"}}`)
+
+		require.Len(t, violations, 2)
+		assert.Equal(t, ".scores.3", violations[0].Path)
+		assert.Equal(t, validation.PathKindValue, violations[0].PathKind)
+		assert.Equal(t, ".scores.two", violations[1].Path)
+		assert.Equal(t, validation.PathKindKey, violations[1].PathKind)
+	})
+
+	t.Run("should return violations for unknown fields", func(t *testing.T) {
+		_, violations := bind(t, `{"name": "Bob", "age": 1, "nope": 1, "address": {"street": "a", "city": "b"}, "extra": {"any": 1}}`)
+
+		assert.Equal(t, []string{".address.city", ".nope"}, deepPaths(violations))
+		assert.Equal(t, []string{validation.CodeUnknownField, validation.CodeUnknownField}, violationCodes(violations))
+	})
+
+	t.Run("should not promote the fields of embedded structs with a JSON name", func(t *testing.T) {
+		var target struct {
+			bindEmbedded `json:"embedded"`
+		}
+
+		binder := validation.JSONBinder{}
+		violations, err := binder.Bind(context.Background(), strings.NewReader(`{"nickname": "a", "embedded": {"nickname": "b"}}`), &target)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{".nickname"}, deepPaths(violations))
+		assert.Equal(t, []string{validation.CodeUnknownField}, violationCodes(violations))
+		assert.Equal(t, "b", target.Nickname)
+	})
+
+	t.Run("should match field names without regard to case", func(t *testing.T) {
+		target, violations := bind(t, `{"NAME": "Bob", "Age": 1}`)
+
+		assert.Empty(t, violations)
+		assert.Equal(t, "Bob", target.Name)
+	})
+
+	t.Run("should allow unknown fields if configured to", func(t *testing.T) {
+		var target bindTester
+
+		binder := validation.JSONBinder{AllowUnknownFields: true}
+		violations, err := binder.Bind(context.Background(), strings.NewReader(`{"name": "Bob", "age": 1, "nope": 1}`), &target, bindConstraints())
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should leave out violations of values that couldn't be decoded", func(t *testing.T) {
+		_, violations := bind(t, `{"name": "Bob", "age": 1, "address": "1 Main Street"}`)
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".address", violations[0].Path)
+		assert.Equal(t, validation.CodeInvalidType, violations[0].Code)
+		assert.Equal(t, "object", violations[0].Details["expected"])
+	})
+
+	t.Run("should leave fields with the string option to encoding/json", func(t *testing.T) {
+		target, violations := bind(t, `{"name": "Bob", "age": 1, "count": "12"}`)
+
+		assert.Empty(t, violations)
+		assert.Equal(t, 12, target.Count)
+
+		_, violations = bind(t, `{"name": "Bob", "age": 1, "count": "twelve"}`)
+		assert.Equal(t, []string{validation.CodeInvalidJSON}, violationCodes(violations))
+	})
+
+	t.Run("should return only a single violation for invalid JSON", func(t *testing.T) {
+		for _, body := range []string{``, `{"name": `, `{"name" "Bob"}`, `{} {}`} {
+			_, violations := bind(t, body)
+
+			require.Len(t, violations, 1, body)
+			assert.Equal(t, ".", violations[0].Path)
+			assert.Equal(t, validation.CodeInvalidJSON, violations[0].Code)
+		}
+	})
+
+	t.Run("should return an error if reading fails", func(t *testing.T) {
+		var target bindTester
+
+		_, err := validation.BindJSON(errReader{}, &target)
+		assert.EqualError(t, err, "read failed")
+	})
+
+	t.Run("should panic if the target is not a pointer", func(t *testing.T) {
+		assert.Panics(t, func() {
+			_, _ = validation.BindJSON(strings.NewReader(`{}`), bindTester{})
+		})
+	})
+}
+
+type bindUntaggedName struct {
+	Name  string
+	Other string
+}
+
+type bindOtherUntaggedName struct {
+	Name string
+}
+
+type bindTaggedName struct {
+	Name string `json:"name"`
+}
+
+type bindOtherTaggedName struct {
+	Name string `json:"name"`
+}
+
+type bindTaggedUpperName struct {
+	X string `json:"Name"`
+}
+
+type bindDeepName struct {
+	bindUntaggedName
+}
+
+type bindOtherDeepName struct {
+	bindUntaggedName
+}
+
+func TestJSONBinder_Bind_embedded(t *testing.T) {
+	tt := []struct {
+		name   string
+		target func() any
+	}{
+		{"untagged fields at the same depth", func() any {
+			return &struct {
+				bindUntaggedName
+				bindOtherUntaggedName
+			}{}
+		}},
+		{"tagged fields at the same depth", func() any {
+			return &struct {
+				bindTaggedName
+				*bindOtherTaggedName
+			}{}
+		}},
+		{"a tagged and an untagged field at the same depth", func() any {
+			return &struct {
+				bindTaggedUpperName
+				bindOtherUntaggedName
+			}{}
+		}},
+		{"fields that are ambiguous in Go, but not in JSON", func() any {
+			return &struct {
+				bindUntaggedName
+				bindTaggedName
+			}{}
+		}},
+		{"fields shadowed by shallower fields", func() any {
+			return &struct {
+				Name string
+				bindUntaggedName
+			}{}
+		}},
+		{"fields shadowed by shallower embedded fields", func() any {
+			return &struct {
+				bindDeepName
+				bindOtherUntaggedName
+			}{}
+		}},
+		{"the same struct embedded twice at the same depth", func() any {
+			return &struct {
+				bindDeepName
+				bindOtherDeepName
+			}{}
+		}},
+	}
+
+	for _, tc := range tt {
+		t.Run("should decode the same fields as encoding/json with "+tc.name, func(t *testing.T) {
+			for _, member := range []string{"Name", "name", "Other", "X"} {
+				body := `{"` + member + `": "x"}`
+
+				decoder := json.NewDecoder(strings.NewReader(body))
+				decoder.DisallowUnknownFields()
+				known := decoder.Decode(tc.target()) == nil
+
+				violations, err := validation.JSONBinder{}.Bind(context.Background(), strings.NewReader(body), tc.target())
+				require.NoError(t, err)
+				assert.Equal(t, known, len(violations) == 0, member)
+
+				if !known {
+					continue
+				}
+
+				// The member is decoded into a field of the same type too.
+				body = `{"` + member + `": 1}`
+
+				var typeErr *json.UnmarshalTypeError
+				require.ErrorAs(t, json.Unmarshal([]byte(body), tc.target()), &typeErr, member)
+
+				violations, err = validation.JSONBinder{}.Bind(context.Background(), strings.NewReader(body), tc.target())
+				require.NoError(t, err)
+				assert.Equal(t, []string{validation.CodeInvalidType}, violationCodes(violations), member)
+			}
+		})
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
@@ -0,0 +1,311 @@
+package validation
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// structInfoCache holds a *structInfo for each struct type that has been validated, keyed by its
+// reflect.Type. Types are never evicted, as a program only has a fixed set of them.
+var structInfoCache sync.Map
+
+// structInfo holds information about a struct type that is otherwise repeatedly looked up using
+// reflection during validation.
+type structInfo struct {
+	// fields contains every field accessible by name, including promoted fields.
+	fields map[string]*fieldInfo
+	// own contains the exported fields declared directly on the struct, in declaration order.
+	own []*fieldInfo
+}
+
+// fieldInfo holds information about a single struct field, including fields promoted from embedded
+// structs.
+type fieldInfo struct {
+	field       reflect.StructField
+	defaultName string
+}
+
+// name returns the output name of this field for the given struct tag.
+func (fi *fieldInfo) name(structTag string) string {
+	if structTag == DefaultNameStructTag {
+		return fi.defaultName
+	}
+
+	return structFieldName(fi.field, structTag)
+}
+
+// cachedStructInfo returns the structInfo for the given struct type, building and caching it if it
+// hasn't been seen before.
+func cachedStructInfo(typ reflect.Type) *structInfo {
+	if si, ok := structInfoCache.Load(typ); ok {
+		return si.(*structInfo)
+	}
+
+	si := &structInfo{
+		fields: make(map[string]*fieldInfo),
+	}
+
+	for _, visible := range reflect.VisibleFields(typ) {
+		// FieldByName is used to find the field that is actually accessible by this name, it
+		// excludes fields with ambiguous names, just like it would if called at validation-time.
+		field, ok := typ.FieldByName(visible.Name)
+		if !ok {
+			continue
+		}
+
+		si.fields[field.Name] = &fieldInfo{
+			field:       field,
+			defaultName: structFieldName(field, DefaultNameStructTag),
+		}
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fi, ok := si.fields[field.Name]
+		if !ok || len(fi.field.Index) != 1 {
+			fi = &fieldInfo{
+				field:       field,
+				defaultName: structFieldName(field, DefaultNameStructTag),
+			}
+		}
+
+		si.own = append(si.own, fi)
+	}
+
+	actual, _ := structInfoCache.LoadOrStore(typ, si)
+	return actual.(*structInfo)
+}
+
+// Compile returns a Constraint that behaves exactly like the given Constraint, but that has been
+// prepared ahead of time for validating values of the given type. Field indexes, output names, and
+// kind checks are resolved once, instead of every time a value is validated, which can make a
+// noticeable difference when the same constraints are used to validate many values.
+//
+// Compile descends through Constraints, Fields, OrderedFields, Elements, Keys, Map, and Struct
+// constraints. Other constraints, like When, or Lazy, are used as-is, as their constraints are only
+// known at validation-time. If a value of some other type is validated, the original constraints
+// are used, so a compiled Constraint is always safe to use in place of the original.
+//
+// Compiled constraints also know how deep into a value they descend, so the path to each value
+// validated is built up without having to grow it at every level.
+func Compile(typ reflect.Type, constraint Constraint) Constraint {
+	switch c := constraint.(type) {
+	case Constraints:
+		compiled := make(Constraints, 0, len(c))
+		for _, cc := range c {
+			compiled = append(compiled, Compile(typ, cc))
+		}
+
+		return compiled
+	case Fields:
+		return compileFields(typ, c)
+	case OrderedFields:
+		return compileOrderedFields(typ, c)
+	case Elements:
+		utyp := UnwrapType(typ)
+		switch utyp.Kind() {
+		case reflect.Array, reflect.Map, reflect.Slice:
+			return Elements(Compile(utyp.Elem(), Constraints(c)).(Constraints))
+		}
+	case Keys:
+		utyp := UnwrapType(typ)
+		if utyp.Kind() == reflect.Map {
+			return Keys(Compile(utyp.Key(), Constraints(c)).(Constraints))
+		}
+	case Map:
+		utyp := UnwrapType(typ)
+		if utyp.Kind() == reflect.Map {
+			compiled := make(Map, len(c))
+			for key, cc := range c {
+				compiled[key] = Compile(utyp.Elem(), cc)
+			}
+
+			return compiled
+		}
+	case *structConstraint:
+		if UnwrapType(typ) == c.typ {
+			compiled := &structConstraint{
+				typ:    c.typ,
+				fields: make([]structField, 0, len(c.fields)),
+			}
+
+			for _, sf := range c.fields {
+				compiled.fields = append(compiled.fields, structField{
+					field:      sf.field,
+					constraint: Compile(sf.field.Type, sf.constraint),
+				})
+			}
+
+			return compiled
+		}
+	}
+
+	return constraint
+}
+
+// compileFields returns a compiled version of the given Fields constraint for the given type, or
+// the original Fields if it can't be compiled for that type.
+func compileFields(typ reflect.Type, f Fields) Constraint {
+	utyp := UnwrapType(typ)
+	if utyp.Kind() != reflect.Struct {
+		return f
+	}
+
+	si := cachedStructInfo(utyp)
+
+	compiled := &compiledFields{
+		typ:      utyp,
+		original: f,
+		fields:   make([]compiledField, 0, len(f)),
+	}
+
+	for fieldName, constraint := range f {
+		fi, ok := si.fields[fieldName]
+		if !ok {
+			// Leave it to the original constraint to report the missing field at validation-time.
+			return f
+		}
+
+		compiled.fields = append(compiled.fields, compiledField{
+			info:       fi,
+			constraint: Compile(fi.field.Type, constraint),
+		})
+	}
+
+	// Fields are stored in a map, so we sort them to be consistent across runs.
+	slices.SortFunc(compiled.fields, func(a, b compiledField) int {
+		return slices.Compare(a.info.field.Index, b.info.field.Index)
+	})
+
+	compiled.depth = compiled.fieldsDepth()
+
+	return compiled
+}
+
+// compileOrderedFields returns a compiled version of the given OrderedFields constraint for the
+// given type, or the original OrderedFields if it can't be compiled for that type.
+func compileOrderedFields(typ reflect.Type, of OrderedFields) Constraint {
+	utyp := UnwrapType(typ)
+	if utyp.Kind() != reflect.Struct {
+		return of
+	}
+
+	si := cachedStructInfo(utyp)
+
+	compiled := &compiledFields{
+		typ:      utyp,
+		original: of,
+		fields:   make([]compiledField, 0, len(of)),
+	}
+
+	for _, field := range of {
+		fi, ok := si.fields[field.Name]
+		if !ok {
+			return of
+		}
+
+		compiled.fields = append(compiled.fields, compiledField{
+			info:       fi,
+			constraint: Compile(fi.field.Type, field.Constraint),
+		})
+	}
+
+	compiled.depth = compiled.fieldsDepth()
+
+	return compiled
+}
+
+// compiledFields is a Fields, or OrderedFields constraint that has been compiled for a specific
+// struct type.
+type compiledFields struct {
+	typ      reflect.Type
+	original Constraint
+	fields   []compiledField
+	// depth is how many values deep these fields' constraints descend, including the fields.
+	depth int
+}
+
+// compiledField is a field of a compiledFields constraint.
+type compiledField struct {
+	info       *fieldInfo
+	constraint Constraint
+}
+
+// Violations ...
+func (cf *compiledFields) Violations(ctx Context) []ConstraintViolation {
+	rval := UnwrapValue(ctx.Value().Node)
+	if !rval.IsValid() || rval.Type() != cf.typ {
+		return ctx.apply(cf.original)
+	}
+
+	// A value is added to Values at every level validation descends to, so room is made for all of
+	// them at once. Values at the same level are validated one after another, and so reuse it.
+	if cap(ctx.Values)-len(ctx.Values) < cf.depth {
+		ctx.Values = slices.Grow(ctx.Values, cf.depth)
+	}
+
+	violations := ctx.each(len(cf.fields), func(ctx Context, i int) []ConstraintViolation {
+		fi := cf.fields[i].info
+
+		ctx = ctx.WithField(fi.field.Name, fi.name(ctx.StructTag), rval.FieldByIndex(fi.field.Index))
+		if ctx.outsideMask {
+			return nil
+		}
+
+		return ctx.apply(cf.fields[i].constraint)
+	})
+
+	return ctx.limit(violations)
+}
+
+// fieldsDepth returns how many values deep the constraints of these fields descend, including the
+// fields themselves.
+func (cf *compiledFields) fieldsDepth() int {
+	depth := 0
+	for _, field := range cf.fields {
+		depth = max(depth, compiledDepth(field.constraint))
+	}
+
+	return depth + 1
+}
+
+// compiledDepth returns how many values deep the given (compiled) constraint is known to descend.
+// Constraints that are only known at validation-time, like Lazy, don't count.
+func compiledDepth(constraint Constraint) int {
+	switch c := constraint.(type) {
+	case Constraints:
+		depth := 0
+		for _, cc := range c {
+			depth = max(depth, compiledDepth(cc))
+		}
+
+		return depth
+	case Elements:
+		return compiledDepth(Constraints(c)) + 1
+	case Keys:
+		return compiledDepth(Constraints(c)) + 1
+	case Map:
+		depth := 0
+		for _, cc := range c {
+			depth = max(depth, compiledDepth(cc))
+		}
+
+		return depth + 1
+	case *structConstraint:
+		depth := 0
+		for _, sf := range c.fields {
+			depth = max(depth, compiledDepth(sf.constraint))
+		}
+
+		return depth + 1
+	case *compiledFields:
+		return c.depth
+	}
+
+	return 0
+}
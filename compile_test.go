@@ -0,0 +1,156 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type compileTester struct {
+	TestSubject
+
+	Name   string            `validation:"name" json:"display_name"`
+	Items  []compileItem     `validation:"items"`
+	Labels map[string]string `validation:"labels"`
+	Parent *compileTester    `validation:"parent"`
+}
+
+type compileItem struct {
+	ID   int `validation:"id"`
+	Tags []string
+}
+
+func compileTesterConstraints() Constraint {
+	return Constraints{
+		Fields{
+			"Name": &TestConstraint{},
+			"Text": &TestConstraint{},
+			"Items": Elements{
+				Fields{
+					"ID":   &TestConstraint{},
+					"Tags": Elements{&TestConstraint{}},
+				},
+			},
+			"Labels": Constraints{
+				Keys{&TestConstraint{}},
+				Map{"foo": &TestConstraint{}},
+			},
+			"Parent": Fields{
+				"Name": &TestConstraint{},
+			},
+		},
+		Struct(func(s *StructRules[compileTester]) {
+			s.Field(func(t *compileTester) *[]compileItem { return &t.Items }, Elements{
+				Fields{"ID": &TestConstraint{}},
+			})
+		}),
+	}
+}
+
+func TestCompile(t *testing.T) {
+	value := compileTester{
+		Items: []compileItem{
+			{ID: 1, Tags: []string{"a", "b"}},
+			{ID: 2},
+		},
+		Labels: map[string]string{"foo": "bar", "baz": "qux"},
+		Parent: &compileTester{},
+	}
+
+	t.Run("should produce the same violations as the original constraints", func(t *testing.T) {
+		constraint := compileTesterConstraints()
+		compiled := Compile(reflect.TypeOf(value), constraint)
+
+		expected := Validate(value, constraint)
+		actual := Validate(value, compiled)
+
+		require.NotEmpty(t, actual)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should produce the same violations when given a pointer type", func(t *testing.T) {
+		constraint := compileTesterConstraints()
+		compiled := Compile(reflect.TypeOf(&value), constraint)
+
+		assert.Equal(t, Validate(&value, constraint), Validate(&value, compiled))
+	})
+
+	t.Run("should use the struct tag on the context", func(t *testing.T) {
+		constraint := Fields{"Name": &TestConstraint{}}
+		compiled := Compile(reflect.TypeOf(value), constraint)
+
+		ctx := NewContext(value)
+		ctx.StructTag = "json"
+
+		violations := ValidateContext(ctx, compiled)
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".display_name", violations[0].Path)
+	})
+
+	t.Run("should fall back to the original constraints when validating another type", func(t *testing.T) {
+		testConstraint := &TestConstraint{}
+
+		compiled := Compile(reflect.TypeOf(value), Fields{"Text": testConstraint})
+
+		violations := Validate(TestSubject{}, compiled)
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".text", violations[0].Path)
+		assert.Equal(t, 1, testConstraint.Calls)
+	})
+
+	t.Run("should still panic at validation-time if a field doesn't exist", func(t *testing.T) {
+		compiled := Compile(reflect.TypeOf(value), Fields{"Missing": &TestConstraint{}})
+
+		assert.Panics(t, func() {
+			Validate(value, compiled)
+		})
+	})
+
+	t.Run("should return constraints it can't compile as-is", func(t *testing.T) {
+		constraint := &TestConstraint{}
+		assert.Same(t, constraint, Compile(reflect.TypeOf(value), constraint))
+	})
+
+	t.Run("should return violations if the given type is not allowed", func(t *testing.T) {
+		compiled := Compile(reflect.TypeOf(value), Fields{"Name": &TestConstraint{}})
+
+		violations := Validate("hello world", compiled)
+		assert.Len(t, violations, 1)
+	})
+
+	t.Run("should allocate less than the original constraints", func(t *testing.T) {
+		noop := ConstraintFunc(func(ctx Context) []ConstraintViolation { return nil })
+		constraint := Fields{
+			"Name": noop,
+			"Items": Elements{
+				Fields{
+					"ID":   noop,
+					"Tags": Elements{noop},
+				},
+			},
+			"Parent": Fields{
+				"Name": noop,
+			},
+		}
+
+		compiled := Compile(reflect.TypeOf(value), constraint)
+
+		original := testing.AllocsPerRun(100, func() { Validate(value, constraint) })
+		actual := testing.AllocsPerRun(100, func() { Validate(value, compiled) })
+
+		assert.Less(t, actual, original)
+	})
+
+	t.Run("should stop descending once cancelled", func(t *testing.T) {
+		ctx, testConstraint := cancellingContext(t, value)
+
+		Compile(reflect.TypeOf(value), Fields{
+			"Name": testConstraint,
+			"Text": testConstraint,
+		}).Violations(ctx)
+
+		assert.Equal(t, 1, testConstraint.Calls)
+	})
+}
@@ -3,25 +3,68 @@ package validation
 import (
 	"fmt"
 	"reflect"
-	"sort"
+	"slices"
+	"sync"
 )
 
 // Constraints is simply a collection of many constraints. All of the constraints will be run, and
-// their results will be aggregated and returned.
+// their results will be aggregated and returned. If the Context has FailFast or BailPerPath set,
+// then constraints are run in order, and the remaining constraints may be skipped.
+//
+// Violations are sorted by path, comparing any numbers in paths (e.g. indexes) by their value, so
+// "[2]" comes before "[10]". Violations with the same path are kept in the order they were produced
+// in, which is deterministic. If the Context has PreserveOrder set, violations aren't sorted.
 type Constraints []Constraint
 
 // Violations ...
 func (cc Constraints) Violations(ctx Context) []ConstraintViolation {
+	violations := cc.violations(ctx)
+	if !ctx.PreserveOrder {
+		sortViolations(violations)
+	}
+
+	return violations
+}
+
+// violations runs each of the constraints in order against the given Context, without sorting the
+// results, stopping early if the Context requires it.
+func (cc Constraints) violations(ctx Context) []ConstraintViolation {
+	if ctx.outsideMask {
+		return nil
+	}
+
+	var path string
+	if ctx.BailPerPath {
+		path = ctx.path()
+	}
+
 	var violations []ConstraintViolation
 	for _, c := range cc {
-		violations = append(violations, c.Violations(ctx)...)
+		if ctx.shouldStop(violations) {
+			break
+		}
+
+		cViolations := ctx.apply(c)
+		violations = append(violations, cViolations...)
+
+		if ctx.BailPerPath && hasViolationAt(cViolations, path, ctx.PathKind) {
+			break
+		}
 	}
 
-	sort.Slice(violations, func(i, j int) bool {
-		return violations[i].Path < violations[j].Path
-	})
+	return ctx.limit(violations)
+}
 
-	return violations
+// hasViolationAt returns true if any of the given violations are error-level violations for the
+// given path.
+func hasViolationAt(violations []ConstraintViolation, path string, pathKind PathKind) bool {
+	for _, violation := range violations {
+		if violation.Path == path && violation.PathKind == pathKind && violation.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Elements is a Constraint used to validate every value (element) in an array, a map, or a slice.
@@ -47,29 +90,26 @@ func (e Elements) Violations(ctx Context) []ConstraintViolation {
 		return nil
 	}
 
-	for _, constraint := range e {
-		switch rval.Kind() {
-		case reflect.Map:
-			iter := rval.MapRange()
-			for iter.Next() {
-				key := iter.Key()
-				value := iter.Value()
-
-				ctx := ctx.WithValue(valueString(key), value)
-				violations = append(violations, constraint.Violations(ctx)...)
-			}
-		case reflect.Array, reflect.Slice:
-			for i := 0; i < rval.Len(); i++ {
-				ctx := ctx.WithValue(fmt.Sprintf("[%d]", i), rval.Index(i))
-				violations = append(violations, constraint.Violations(ctx)...)
-			}
-		}
+	switch rval.Kind() {
+	case reflect.Map:
+		keys := rval.MapKeys()
+		sortMapKeys(keys)
+
+		violations = ctx.each(len(keys), func(ctx Context, i int) []ConstraintViolation {
+			return Constraints(e).violations(ctx.WithKey(keys[i], rval.MapIndex(keys[i])))
+		})
+	case reflect.Array, reflect.Slice:
+		violations = ctx.each(rval.Len(), func(ctx Context, i int) []ConstraintViolation {
+			return Constraints(e).violations(ctx.WithIndex(i, rval.Index(i)))
+		})
 	}
 
-	return violations
+	return ctx.limit(violations)
 }
 
-// Fields is a Constraint used to validate the values of specific fields on a struct.
+// Fields is a Constraint used to validate the values of specific fields on a struct. Fields are
+// validated in the order they're declared in on the struct. To validate fields in some other order,
+// use OrderedFields instead.
 type Fields map[string]Constraint
 
 // Violations ...
@@ -86,12 +126,36 @@ func (f Fields) Violations(ctx Context) []ConstraintViolation {
 		return violations
 	}
 
-	for fieldName, constraint := range f {
-		ctx := ctx.WithValue(FieldName(ctx, fieldName), rval.FieldByName(fieldName))
-		violations = append(violations, constraint.Violations(ctx)...)
+	si := cachedStructInfo(rtyp)
+
+	fields := make([]*fieldInfo, 0, len(f))
+	for fieldName := range f {
+		fi, ok := si.fields[fieldName]
+		if !ok {
+			// TODO: More info, like type? Let's see what the stack trace looks like first.
+			panic(fmt.Sprintf("validation: field '%s' does not exist", fieldName))
+		}
+
+		fields = append(fields, fi)
 	}
 
-	return violations
+	// Fields is a map, so we sort the fields to validate them in a consistent order.
+	slices.SortFunc(fields, func(a, b *fieldInfo) int {
+		return slices.Compare(a.field.Index, b.field.Index)
+	})
+
+	violations = ctx.each(len(fields), func(ctx Context, i int) []ConstraintViolation {
+		fi := fields[i]
+
+		ctx = ctx.WithField(fi.field.Name, fi.name(ctx.StructTag), rval.FieldByIndex(fi.field.Index))
+		if ctx.outsideMask {
+			return nil
+		}
+
+		return ctx.apply(f[fi.field.Name])
+	})
+
+	return ctx.limit(violations)
 }
 
 // Keys is a Constraint used to validate the keys of a map.
@@ -115,14 +179,14 @@ func (k Keys) Violations(ctx Context) []ConstraintViolation {
 		return nil
 	}
 
-	for _, constraint := range k {
-		for _, key := range rval.MapKeys() {
-			ctx := ctx.WithValue(valueString(key), key).WithPathKind(PathKindKey)
-			violations = append(violations, constraint.Violations(ctx)...)
-		}
-	}
+	keys := rval.MapKeys()
+	sortMapKeys(keys)
 
-	return violations
+	violations = ctx.each(len(keys), func(ctx Context, i int) []ConstraintViolation {
+		return Constraints(k).violations(ctx.WithKey(keys[i], keys[i]).WithPathKind(PathKindKey))
+	})
+
+	return ctx.limit(violations)
 }
 
 // Lazy is a Constraint that allows a function that returns Constraints to be evaluated at
@@ -131,7 +195,7 @@ type Lazy func() Constraint
 
 // Violations ...
 func (f Lazy) Violations(ctx Context) []ConstraintViolation {
-	return f().Violations(ctx)
+	return ctx.apply(f())
 }
 
 // LazyDynamic is a Constraint that's extremely similar to Lazy, and fulfils mostly the same
@@ -146,6 +210,12 @@ func LazyDynamic(constraintFn any) Constraint {
 // lazyDynamic is the implementation of the LazyDynamic constraint.
 type lazyDynamic struct {
 	constraintFn any
+
+	// The constraint function's signature is checked once, the first time it's needed.
+	once    sync.Once
+	rfn     reflect.Value
+	argType reflect.Type
+	err     string
 }
 
 // constraintType is kept on it's own here because it won't change, we don't need to fetch it every
@@ -166,19 +236,13 @@ func (ld *lazyDynamic) Violations(ctx Context) []ConstraintViolation {
 		return violations
 	}
 
-	rfn := reflect.ValueOf(ld.constraintFn)
-	rfnt := rfn.Type()
-
-	if rfnt.NumIn() != 1 {
-		panic("validation: LazyDynamic expects a function that accepts a single argument of the type being validated (or it's unwrapped value type)")
-	}
-
-	if rfnt.NumOut() != 1 || rfnt.Out(0) != constraintType {
-		panic("validation: LazyDynamic expects a function that returns a Constraint")
+	ld.once.Do(ld.checkSignature)
+	if ld.err != "" {
+		panic(ld.err)
 	}
 
-	isContextType := rfnt.In(0) == ctx.Value().Node.Type()
-	isUnwrappedType := rfnt.In(0) == rval.Type()
+	isContextType := ld.argType == ctx.Value().Node.Type()
+	isUnwrappedType := ld.argType == rval.Type()
 
 	if !isContextType && !isUnwrappedType {
 		panic("validation: LazyDynamic expects a function that accepts a single argument of the type being validated (or it's unwrapped value type)")
@@ -186,12 +250,31 @@ func (ld *lazyDynamic) Violations(ctx Context) []ConstraintViolation {
 
 	var constraint Constraint
 	if isUnwrappedType {
-		constraint = rfn.Call([]reflect.Value{rval})[0].Interface().(Constraint)
+		constraint = ld.rfn.Call([]reflect.Value{rval})[0].Interface().(Constraint)
 	} else {
-		constraint = rfn.Call([]reflect.Value{ctx.Value().Node})[0].Interface().(Constraint)
+		constraint = ld.rfn.Call([]reflect.Value{ctx.Value().Node})[0].Interface().(Constraint)
+	}
+
+	return ctx.apply(constraint)
+}
+
+// checkSignature checks that the constraint function has a valid signature, recording an error to
+// panic with if it doesn't.
+func (ld *lazyDynamic) checkSignature() {
+	ld.rfn = reflect.ValueOf(ld.constraintFn)
+	rfnt := ld.rfn.Type()
+
+	if rfnt.NumIn() != 1 {
+		ld.err = "validation: LazyDynamic expects a function that accepts a single argument of the type being validated (or it's unwrapped value type)"
+		return
+	}
+
+	if rfnt.NumOut() != 1 || rfnt.Out(0) != constraintType {
+		ld.err = "validation: LazyDynamic expects a function that returns a Constraint"
+		return
 	}
 
-	return constraint.Violations(ctx)
+	ld.argType = rfnt.In(0)
 }
 
 // Map is a Constraint used to validate a map. This Constraint validates the values in the map, by
@@ -213,50 +296,73 @@ func (m Map) Violations(ctx Context) []ConstraintViolation {
 		return violations
 	}
 
-	for mapKey, constraint := range m {
-		value := rval.MapIndex(reflect.ValueOf(mapKey))
+	keys := make([]reflect.Value, 0, len(m))
+	for mapKey := range m {
+		keys = append(keys, reflect.ValueOf(mapKey))
+	}
 
-		ctx := ctx.WithValue(
-			valueString(reflect.ValueOf(mapKey)),
-			value,
-		)
+	sortMapKeys(keys)
 
-		violations = append(violations, constraint.Violations(ctx)...)
-	}
+	violations = ctx.each(len(keys), func(ctx Context, i int) []ConstraintViolation {
+		ctx = ctx.WithKey(keys[i], rval.MapIndex(keys[i]))
+		if ctx.outsideMask {
+			return nil
+		}
 
-	return violations
+		return ctx.apply(m[keys[i].Interface()])
+	})
+
+	return ctx.limit(violations)
 }
 
 // When conditionally runs some constraints. The predicate is set up at the time of creating the
 // constraints. If you want a more dynamic approach, you should use WhenFn instead. You can also
 // build up constraints programmatically and use the value being validated to build the constraints.
 func When(predicate bool, constraints ...Constraint) ConstraintFunc {
-	return func(ctx Context) []ConstraintViolation {
-		var violations []ConstraintViolation
-		if predicate {
-			for _, c := range constraints {
-				violations = append(violations, c.Violations(ctx)...)
-			}
+	return WrapFunc(func(ctx Context) []ConstraintViolation {
+		if !predicate {
+			return nil
 		}
 
-		return violations
-	}
+		return Constraints(constraints).violations(ctx)
+	}, constraints...)
 }
 
 // WhenFn lazily conditionally runs some constraints. The predicate function is called during the
 // validation process. If you need an even more dynamic approach, you can also build up constraints
 // programmatically and use the value being validated to build the constraints.
 func WhenFn(predicateFn func(ctx Context) bool, constraints ...Constraint) ConstraintFunc {
-	return func(ctx Context) []ConstraintViolation {
+	return WrapFunc(func(ctx Context) []ConstraintViolation {
+		if !predicateFn(ctx) {
+			return nil
+		}
+
+		return Constraints(constraints).violations(ctx)
+	}, constraints...)
+}
+
+// Sequence runs the given constraints in order, stopping at the first constraint that returns any
+// error-level violations. This avoids redundant violations, e.g. there's little point checking a
+// value matches a pattern if no value was given at all. To apply this behaviour to every path,
+// rather than only where Sequence is used, set BailPerPath on the Context instead.
+func Sequence(constraints ...Constraint) ConstraintFunc {
+	return DescribeFuncAs(func(ctx Context) []ConstraintViolation {
 		var violations []ConstraintViolation
-		if predicateFn(ctx) {
-			for _, c := range constraints {
-				violations = append(violations, c.Violations(ctx)...)
+		for _, c := range constraints {
+			if ctx.Err() != nil {
+				break
+			}
+
+			cViolations := ctx.apply(c)
+			violations = append(violations, cViolations...)
+
+			if HasErrors(cViolations) {
+				break
 			}
 		}
 
-		return violations
-	}
+		return ctx.limit(violations)
+	}, constraints...)
 }
 
 // valueString returns a string representation of the given value. It handles any type that may be
@@ -25,7 +25,11 @@ func AtLeastNRequired(n int, fields ...string) validation.ConstraintFunc {
 		panic("constraints: value of n given to AtLeastNRequired must be less than the number of fields")
 	}
 
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeAtLeastNRequired, map[string]any{"minimum": n, "fields": fields}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+		if !ctx.AnyFieldInMask(fields...) {
+			return nil
+		}
+
 		fieldNames := make([]string, 0, len(fields))
 
 		var nonEmpty []string
@@ -42,7 +46,7 @@ func AtLeastNRequired(n int, fields ...string) validation.ConstraintFunc {
 
 		if len(nonEmpty) < n {
 			return []validation.ConstraintViolation{
-				ctx.Violation("minimum number of required fields not met", map[string]any{
+				ctx.CodedViolation(CodeAtLeastNRequired, "minimum number of required fields not met", map[string]any{
 					"actual":  len(nonEmpty),
 					"minimum": n,
 					"fields":  fieldNames,
@@ -51,5 +55,5 @@ func AtLeastNRequired(n int, fields ...string) validation.ConstraintFunc {
 		}
 
 		return nil
-	}, reflect.Struct)
+	}, reflect.Struct))
 }
@@ -25,7 +25,11 @@ func AtMostNRequired(n int, fields ...string) validation.ConstraintFunc {
 		panic("constraints: value of n given to AtMostNRequired must be less than the number of fields")
 	}
 
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeAtMostNRequired, map[string]any{"maximum": n, "fields": fields}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+		if !ctx.AnyFieldInMask(fields...) {
+			return nil
+		}
+
 		fieldNames := make([]string, 0, len(fields))
 
 		var nonEmpty []string
@@ -42,7 +46,7 @@ func AtMostNRequired(n int, fields ...string) validation.ConstraintFunc {
 
 		if len(nonEmpty) > n {
 			return []validation.ConstraintViolation{
-				ctx.Violation("maximum number of required fields exceeded", map[string]interface{}{
+				ctx.CodedViolation(CodeAtMostNRequired, "maximum number of required fields exceeded", map[string]interface{}{
 					"actual":  len(nonEmpty),
 					"maximum": n,
 					"fields":  fieldNames,
@@ -51,5 +55,5 @@ func AtMostNRequired(n int, fields ...string) validation.ConstraintFunc {
 		}
 
 		return nil
-	}, reflect.Struct)
+	}, reflect.Struct))
 }
@@ -0,0 +1,91 @@
+package constraints
+
+import "github.com/seeruk/go-validation"
+
+// Codes of the violations produced by the built-in constraints. These are stable, and won't change
+// even if the messages of the violations do, so clients can rely on them to decide how to handle a
+// violation. Custom constraints can set their own codes using validation.Context's CodedViolation,
+// or TemplateViolation, and the code of any constraint can be overridden using Code.
+const (
+	// CodeAtLeastNRequired is the code of violations produced by AtLeastNRequired.
+	CodeAtLeastNRequired = "at_least_n_required"
+	// CodeAtMostNRequired is the code of violations produced by AtMostNRequired.
+	CodeAtMostNRequired = "at_most_n_required"
+	// CodeEmpty is the code of violations produced by Empty.
+	CodeEmpty = "empty"
+	// CodeEquals is the code of violations produced by Equals.
+	CodeEquals = "equals"
+	// CodeExactlyNRequired is the code of violations produced by ExactlyNRequired.
+	CodeExactlyNRequired = "exactly_n_required"
+	// CodeExactlyOneSet is the code of violations produced by ExactlyOneSet.
+	CodeExactlyOneSet = "exactly_one_set"
+	// CodeKind is the code of violations produced by Kind, and any constraint given a value of a
+	// kind that it doesn't support.
+	CodeKind = validation.CodeKind
+	// CodeLength is the code of violations produced by Length.
+	CodeLength = "length"
+	// CodeMax is the code of violations produced by Max.
+	CodeMax = "max"
+	// CodeMaxLength is the code of violations produced by MaxLength.
+	CodeMaxLength = "max_length"
+	// CodeMin is the code of violations produced by Min.
+	CodeMin = "min"
+	// CodeMinLength is the code of violations produced by MinLength.
+	CodeMinLength = "min_length"
+	// CodeMutuallyExclusive is the code of violations produced by MutuallyExclusive.
+	CodeMutuallyExclusive = "mutually_exclusive"
+	// CodeMutuallyInclusive is the code of violations produced by MutuallyInclusive.
+	CodeMutuallyInclusive = "mutually_inclusive"
+	// CodeNil is the code of violations produced by Nil.
+	CodeNil = "nil"
+	// CodeNoError is the code of violations produced by NoError, when the given function returns
+	// an error.
+	CodeNoError = "no_error"
+	// CodeNoneOf is the code of violations produced by NoneOf.
+	CodeNoneOf = "none_of"
+	// CodeNotEquals is the code of violations produced by NotEquals.
+	CodeNotEquals = "not_equals"
+	// CodeNotNil is the code of violations produced by NotNil.
+	CodeNotNil = "not_nil"
+	// CodeOneOf is the code of violations produced by OneOf.
+	CodeOneOf = "one_of"
+	// CodeOneOfKeys is the code of violations produced by OneOfKeys.
+	CodeOneOfKeys = "one_of_keys"
+	// CodeRegexp is the code of violations produced by Regexp.
+	CodeRegexp = "regexp"
+	// CodeRequired is the code of violations produced by Required.
+	CodeRequired = "required"
+	// CodeTimeAfter is the code of violations produced by TimeAfter.
+	CodeTimeAfter = "time_after"
+	// CodeTimeBefore is the code of violations produced by TimeBefore.
+	CodeTimeBefore = "time_before"
+	// CodeType is the code of violations produced by NoError, when the value is not of the type
+	// expected by the given function.
+	CodeType = "type"
+)
+
+// Code sets the code of any violations produced by the given constraint, replacing any code they
+// already have. This can be used to give custom codes to constraints, including Details.
+func Code(c validation.Constraint, code string) validation.ConstraintFunc {
+	fn := func(ctx validation.Context) []validation.ConstraintViolation {
+		violations := c.Violations(ctx)
+		for i := range violations {
+			violations[i].Code = code
+		}
+
+		return violations
+	}
+
+	// The rules of the given constraint are still enforced, but its violations have the given
+	// code, so that's how they're described.
+	descriptions, ok := validation.Describe(c)
+	if !ok {
+		return validation.WrapFunc(fn, c)
+	}
+
+	for i := range descriptions {
+		descriptions[i].Code = code
+	}
+
+	return validation.DescribeFunc(fn, descriptions...)
+}
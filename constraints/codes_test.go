@@ -0,0 +1,86 @@
+package constraints
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestCode(t *testing.T) {
+	t.Run("should return no violations if the constraint argument has no violations", func(t *testing.T) {
+		violations := Code(Required, "custom")(validation.NewContext("test"))
+		assert.Len(t, violations, 0)
+	})
+
+	t.Run("should replace the code of any violations", func(t *testing.T) {
+		violations := Code(Required, "custom")(validation.NewContext(""))
+		require.Len(t, violations, 1)
+		assert.Equal(t, "custom", violations[0].Code)
+	})
+
+	t.Run("should set the code of violations produced by Details", func(t *testing.T) {
+		violations := Code(Details(Required, "name is required"), "name_required")(validation.NewContext(""))
+		require.Len(t, violations, 1)
+		assert.Equal(t, "name_required", violations[0].Code)
+		assert.Equal(t, "name is required", violations[0].Message)
+	})
+}
+
+func TestCodes(t *testing.T) {
+	type testSubject struct {
+		Field1 string
+		Field2 string
+		Field3 string
+	}
+
+	both := testSubject{Field1: "a", Field2: "b"}
+	now := time.Now()
+
+	tt := []struct {
+		code       string
+		constraint validation.ConstraintFunc
+		value      any
+	}{
+		{CodeAtLeastNRequired, AtLeastNRequired(1, "Field1", "Field2"), testSubject{Field3: "c"}},
+		{CodeAtMostNRequired, AtMostNRequired(1, "Field1", "Field2"), both},
+		{CodeEmpty, Empty, "test"},
+		{CodeEquals, Equals("test"), "other"},
+		{CodeExactlyNRequired, ExactlyNRequired(1, "Field1", "Field2"), both},
+		{CodeExactlyOneSet, ExactlyOneSet("kind"), &structpb.Value{}},
+		{CodeKind, Kind(reflect.Int), "test"},
+		{CodeLength, Length(1), "test"},
+		{CodeMax, Max(1), 2},
+		{CodeMaxLength, MaxLength(1), "test"},
+		{CodeMin, Min(2), 1},
+		{CodeMinLength, MinLength(5), "test"},
+		{CodeMutuallyExclusive, MutuallyExclusive("Field1", "Field2"), both},
+		{CodeMutuallyInclusive, MutuallyInclusive("Field1", "Field2", "Field3"), both},
+		{CodeNil, Nil, []string{"test"}},
+		{CodeNoError, NoError(func(string) error { return errors.New("test") }, "test"), "test"},
+		{CodeNoneOf, NoneOf("a", "b"), "a"},
+		{CodeNotEquals, NotEquals("test"), "test"},
+		{CodeNotNil, NotNil, []string(nil)},
+		{CodeOneOf, OneOf("a", "b"), "c"},
+		{CodeOneOfKeys, OneOfKeys("a"), map[string]string{"b": "c"}},
+		{CodeRegexp, Regexp(regexp.MustCompile("^a$")), "b"},
+		{CodeRequired, Required, ""},
+		{CodeTimeAfter, TimeAfter(now), now.Add(-time.Hour)},
+		{CodeTimeBefore, TimeBefore(now), now.Add(time.Hour)},
+		{CodeType, NoError(func(int) error { return nil }, "test"), "test"},
+	}
+
+	for _, tc := range tt {
+		t.Run("should return violations with the "+tc.code+" code", func(t *testing.T) {
+			violations := tc.constraint(validation.NewContext(tc.value))
+			require.Len(t, violations, 1)
+			assert.Equal(t, tc.code, violations[0].Code)
+		})
+	}
+}
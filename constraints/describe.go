@@ -0,0 +1,9 @@
+package constraints
+
+import "github.com/seeruk/go-validation"
+
+// describe returns the given constraint, described using the given code and parameters, see
+// validation.DescribeFunc.
+func describe(code string, params map[string]any, c validation.ConstraintFunc) validation.ConstraintFunc {
+	return validation.DescribeFunc(c, validation.Description{Code: code, Params: params})
+}
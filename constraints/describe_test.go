@@ -0,0 +1,45 @@
+package constraints
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	tt := []struct {
+		name       string
+		constraint validation.Constraint
+		expected   []validation.Description
+	}{
+		{"Required", Required, []validation.Description{{Code: CodeRequired}}},
+		{"Min", Min(2), []validation.Description{{Code: CodeMin, Params: map[string]any{"minimum": 2.0}}}},
+		{"MaxLength", MaxLength(5), []validation.Description{{Code: CodeMaxLength, Params: map[string]any{"maximum": 5}}}},
+		{"Regexp", Regexp(regexp.MustCompile("^a+$")), []validation.Description{{Code: CodeRegexp, Params: map[string]any{"regexp": "^a+$"}}}},
+		{"OneOf", OneOf("a", "b"), []validation.Description{{Code: CodeOneOf, Params: map[string]any{"allowed": []string{"a", "b"}}}}},
+		{"AtLeastNRequired", AtLeastNRequired(1, "A", "B"), []validation.Description{{Code: CodeAtLeastNRequired, Params: map[string]any{"minimum": 1, "fields": []string{"A", "B"}}}}},
+		{"Code", Code(Min(3), "custom"), []validation.Description{{Code: "custom", Params: map[string]any{"minimum": 3.0}}}},
+		{"Details", Details(MinLength(1), "too short"), []validation.Description{{Code: CodeMinLength, Params: map[string]any{"minimum": 1}}}},
+		{"Template", Template(MinLength(1), "at least {minimum}"), []validation.Description{{Code: CodeMinLength, Params: map[string]any{"minimum": 1}}}},
+	}
+
+	for _, tc := range tt {
+		t.Run("should describe "+tc.name, func(t *testing.T) {
+			descriptions, ok := validation.Describe(tc.constraint)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, descriptions)
+		})
+	}
+
+	t.Run("should not describe downgraded constraints", func(t *testing.T) {
+		_, ok := validation.Describe(AsWarning(Required))
+		assert.False(t, ok)
+	})
+
+	t.Run("should still validate values", func(t *testing.T) {
+		violations := Min(2)(validation.NewContext(1))
+		assert.Len(t, violations, 1)
+	})
+}
@@ -5,16 +5,26 @@ import "github.com/seeruk/go-validation"
 // Details allows you to provide a custom violation message and details for a constraint. This can
 // be used to provide purpose-specific messages and details for constraints, as opposed to the
 // generic messaging that constraints typically provide.
+//
+// The message is used as-is, see Template for messages that reference the details of the original
+// violation.
 func Details(c validation.Constraint, msg string, details ...any) validation.ConstraintFunc {
-	return func(ctx validation.Context) []validation.ConstraintViolation {
+	return validation.DescribeFuncAs(func(ctx validation.Context) []validation.ConstraintViolation {
 		violations := c.Violations(ctx)
 		if len(violations) == 0 {
 			return nil
 		}
-		return []validation.ConstraintViolation{
-			ctx.Violation(msg, detailsMap(details...)),
-		}
-	}
+
+		// The code, severity, and group of the original violation still apply, only the message and
+		// details are customised. Use Code to set a custom code. The message is custom, so it mustn't
+		// be replaced by a translation of the usual message for the code.
+		violation := ctx.CodedViolation(violations[0].Code, msg, detailsMap(details...))
+		violation.Severity = violations[0].Severity
+		violation.Group = violations[0].Group
+		violation.Custom = true
+
+		return []validation.ConstraintViolation{violation}
+	}, c)
 }
 
 // detailsMap converts a variadic list of key/value pairs into a map.
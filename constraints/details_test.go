@@ -29,4 +29,14 @@ func TestDetails(t *testing.T) {
 			"hello": 1,
 		}, violations[0].Details, "should have the given details")
 	})
+	t.Run("should keep the severity of the constraint argument's violations", func(t *testing.T) {
+		violations := Details(AsWarning(Equals("test")), "should happen")(validation.NewContext("not test"))
+		assert.Len(t, violations, 1, "should return a single violation")
+		assert.Equal(t, validation.SeverityWarning, violations[0].Severity, "should be a warning")
+	})
+	t.Run("should keep the code of the constraint argument's violations", func(t *testing.T) {
+		violations := Details(Equals("test"), "should happen")(validation.NewContext("not test"))
+		assert.Len(t, violations, 1, "should return a single violation")
+		assert.Equal(t, CodeEquals, violations[0].Code, "should have the original code")
+	})
 }
@@ -8,7 +8,7 @@ import (
 
 // Equals ...
 func Equals(value any) validation.ConstraintFunc {
-	return func(ctx validation.Context) []validation.ConstraintViolation {
+	return describe(CodeEquals, map[string]any{"expected": value}, func(ctx validation.Context) []validation.ConstraintViolation {
 		rval := validation.UnwrapValue(ctx.Value().Node)
 		if validation.IsEmpty(rval) {
 			return nil
@@ -16,12 +16,12 @@ func Equals(value any) validation.ConstraintFunc {
 
 		if !reflect.DeepEqual(rval.Interface(), value) {
 			return []validation.ConstraintViolation{
-				ctx.Violation("value must equal expected value", map[string]any{
+				ctx.CodedViolation(CodeEquals, "value must equal expected value", map[string]any{
 					"expected": value,
 				}),
 			}
 		}
 
 		return nil
-	}
+	})
 }
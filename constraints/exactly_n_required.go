@@ -25,7 +25,7 @@ func ExactlyNRequired(n int, fields ...string) validation.ConstraintFunc {
 		panic("constraints: value of n given to ExactlyNRequired must be less than the number of fields")
 	}
 
-	return func(ctx validation.Context) []validation.ConstraintViolation {
+	return describe(CodeExactlyNRequired, map[string]any{"expected": n, "fields": fields}, func(ctx validation.Context) []validation.ConstraintViolation {
 		rval := validation.UnwrapValue(ctx.Value().Node)
 		if validation.IsEmpty(rval) {
 			return nil
@@ -38,6 +38,10 @@ func ExactlyNRequired(n int, fields ...string) validation.ConstraintFunc {
 			return violations
 		}
 
+		if !ctx.AnyFieldInMask(fields...) {
+			return nil
+		}
+
 		fieldNames := make([]string, 0, len(fields))
 
 		var nonEmpty []string
@@ -54,7 +58,7 @@ func ExactlyNRequired(n int, fields ...string) validation.ConstraintFunc {
 
 		if len(nonEmpty) != n {
 			return []validation.ConstraintViolation{
-				ctx.Violation("exact number of required fields not met", map[string]any{
+				ctx.CodedViolation(CodeExactlyNRequired, "exact number of required fields not met", map[string]any{
 					"actual":   len(nonEmpty),
 					"expected": n,
 					"fields":   fieldNames,
@@ -63,5 +67,5 @@ func ExactlyNRequired(n int, fields ...string) validation.ConstraintFunc {
 		}
 
 		return nil
-	}
+	})
 }
@@ -0,0 +1,76 @@
+package constraints
+
+import (
+	"fmt"
+
+	"github.com/seeruk/go-validation"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ExactlyOneSet returns a Constraint that requires exactly one of the given fields of a ProtoBuf
+// message to be set. Names may be those of fields (either their ProtoBuf, or JSON names), or of
+// oneofs, in which case any field in the oneof being set counts. Field presence is respected, so
+// optional fields, and fields in a oneof are set even if they're set to their zero value.
+func ExactlyOneSet(names ...string) validation.ConstraintFunc {
+	if len(names) == 0 {
+		panic("constraints: at least one field or oneof name must be given to ExactlyOneSet")
+	}
+
+	return describe(CodeExactlyOneSet, map[string]any{"fields": names}, func(ctx validation.Context) []validation.ConstraintViolation {
+		msg, violations := validation.ProtoMessage(ctx)
+		if msg == nil {
+			return violations
+		}
+
+		md := msg.Descriptor()
+
+		fieldNames := make([]string, 0, len(names))
+		segments := make([]validation.PathSegment, 0, len(names))
+
+		var set []string
+		for _, name := range names {
+			if od := md.Oneofs().ByName(protoreflect.Name(name)); od != nil {
+				// Oneofs don't appear in paths themselves, only their fields do.
+				for i := 0; i < od.Fields().Len(); i++ {
+					fd := od.Fields().Get(i)
+					segments = append(segments, validation.FieldSegment(string(fd.Name()), validation.ProtoFieldName(ctx, fd)))
+				}
+
+				fieldNames = append(fieldNames, name)
+				if msg.WhichOneof(od) != nil {
+					set = append(set, name)
+				}
+
+				continue
+			}
+
+			fd := validation.ProtoField(md, name)
+			if fd == nil {
+				panic(fmt.Sprintf("constraints: field or oneof '%s' does not exist on message '%s'", name, md.FullName()))
+			}
+
+			fieldName := validation.ProtoFieldName(ctx, fd)
+			fieldNames = append(fieldNames, fieldName)
+			segments = append(segments, validation.FieldSegment(string(fd.Name()), fieldName))
+
+			if msg.Has(fd) {
+				set = append(set, fieldName)
+			}
+		}
+
+		if !ctx.AnySegmentInMask(segments...) {
+			return nil
+		}
+
+		if len(set) != 1 {
+			return []validation.ConstraintViolation{
+				ctx.CodedViolation(CodeExactlyOneSet, "exactly one field must be set", map[string]any{
+					"actual": len(set),
+					"fields": fieldNames,
+				}),
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,82 @@
+package constraints
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestExactlyOneSet(t *testing.T) {
+	t.Run("should panic if no names are given", func(t *testing.T) {
+		assert.Panics(t, func() {
+			ExactlyOneSet()
+		})
+	})
+
+	t.Run("should not return a violation if a field in a oneof is set", func(t *testing.T) {
+		// The null value is the zero value of its enum, but is still set.
+		violations := ExactlyOneSet("kind")(validation.NewContext(structpb.NewNullValue()))
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should return a violation if no field in a oneof is set", func(t *testing.T) {
+		violations := ExactlyOneSet("kind")(validation.NewContext(&structpb.Value{}))
+		require.Len(t, violations, 1)
+		assert.Equal(t, CodeExactlyOneSet, violations[0].Code)
+		assert.Equal(t, "exactly one field must be set", violations[0].Message)
+	})
+
+	t.Run("should respect the presence of fields", func(t *testing.T) {
+		constraint := ExactlyOneSet("type_name", "extendee")
+
+		assert.Empty(t, constraint(validation.NewContext(&descriptorpb.FieldDescriptorProto{
+			TypeName: proto.String(""),
+		})))
+
+		assert.Len(t, constraint(validation.NewContext(&descriptorpb.FieldDescriptorProto{})), 1)
+		assert.Len(t, constraint(validation.NewContext(&descriptorpb.FieldDescriptorProto{
+			TypeName: proto.String("a"),
+			Extendee: proto.String("b"),
+		})), 1)
+	})
+
+	t.Run("should use output names in details", func(t *testing.T) {
+		ctx := validation.NewContext(&descriptorpb.FieldDescriptorProto{}, func(ctx *validation.Context) {
+			ctx.StructTag = "json"
+		})
+
+		violations := ExactlyOneSet("type_name", "extendee")(ctx)
+		require.Len(t, violations, 1)
+		assert.Equal(t, []string{"typeName", "extendee"}, violations[0].Details["fields"])
+	})
+
+	t.Run("should not return a violation if none of the fields are in the field mask", func(t *testing.T) {
+		ctx := validation.NewContext(&descriptorpb.FieldDescriptorProto{}, validation.WithFieldMask("name"))
+		assert.Empty(t, ExactlyOneSet("type_name", "extendee")(ctx))
+
+		ctx = validation.NewContext(&descriptorpb.FieldDescriptorProto{}, validation.WithFieldMask("extendee"))
+		assert.Len(t, ExactlyOneSet("type_name", "extendee")(ctx), 1)
+	})
+
+	t.Run("should return no violations for nil messages", func(t *testing.T) {
+		var value *structpb.Value
+		assert.Empty(t, ExactlyOneSet("kind")(validation.NewContext(value)))
+	})
+
+	t.Run("should return a violation if the value is not a message", func(t *testing.T) {
+		violations := ExactlyOneSet("kind")(validation.NewContext("test"))
+		require.Len(t, violations, 1)
+		assert.Equal(t, CodeKind, violations[0].Code)
+	})
+
+	t.Run("should panic if a field does not exist", func(t *testing.T) {
+		assert.Panics(t, func() {
+			ExactlyOneSet("nope")(validation.NewContext(&structpb.Value{}))
+		})
+	})
+}
@@ -10,10 +10,10 @@ import (
 func Length(length int) validation.ConstraintFunc {
 	allowed := []reflect.Kind{reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String}
 
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeLength, map[string]any{"expected": length}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
 		if rval.Len() != length {
 			return []validation.ConstraintViolation{
-				ctx.Violation("exact length not met", map[string]any{
+				ctx.CodedViolation(CodeLength, "exact length not met", map[string]any{
 					"actual":   rval.Len(),
 					"expected": length,
 				}),
@@ -21,5 +21,5 @@ func Length(length int) validation.ConstraintFunc {
 		}
 
 		return nil
-	}, allowed...)
+	}, allowed...))
 }
@@ -14,7 +14,7 @@ func Max(max float64) validation.ConstraintFunc {
 		reflect.Float32, reflect.Float64,
 	}
 
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeMax, map[string]any{"maximum": max}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
 		var actual float64
 
 		switch rval.Kind() {
@@ -28,7 +28,7 @@ func Max(max float64) validation.ConstraintFunc {
 
 		if actual > max {
 			return []validation.ConstraintViolation{
-				ctx.Violation("maximum value exceeded", map[string]any{
+				ctx.CodedViolation(CodeMax, "maximum value exceeded", map[string]any{
 					"actual":  actual,
 					"maximum": max,
 				}),
@@ -36,5 +36,5 @@ func Max(max float64) validation.ConstraintFunc {
 		}
 
 		return nil
-	}, allowed...)
+	}, allowed...))
 }
@@ -10,10 +10,10 @@ import (
 func MaxLength(max int) validation.ConstraintFunc {
 	allowed := []reflect.Kind{reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String}
 
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeMaxLength, map[string]any{"maximum": max}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
 		if rval.Len() > max {
 			return []validation.ConstraintViolation{
-				ctx.Violation("maximum length exceeded", map[string]any{
+				ctx.CodedViolation(CodeMaxLength, "maximum length exceeded", map[string]any{
 					"actual":  rval.Len(),
 					"maximum": max,
 				}),
@@ -21,5 +21,5 @@ func MaxLength(max int) validation.ConstraintFunc {
 		}
 
 		return nil
-	}, allowed...)
+	}, allowed...))
 }
@@ -14,7 +14,7 @@ func Min(min float64) validation.ConstraintFunc {
 		reflect.Float32, reflect.Float64,
 	}
 
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeMin, map[string]any{"minimum": min}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
 		var actual float64
 
 		switch rval.Kind() {
@@ -28,12 +28,12 @@ func Min(min float64) validation.ConstraintFunc {
 
 		if actual < min {
 			return []validation.ConstraintViolation{
-				ctx.Violation("minimum value not met", map[string]any{
+				ctx.CodedViolation(CodeMin, "minimum value not met", map[string]any{
 					"minimum": min,
 				}),
 			}
 		}
 
 		return nil
-	}, allowed...)
+	}, allowed...))
 }
@@ -10,10 +10,10 @@ import (
 func MinLength(min int) validation.ConstraintFunc {
 	allowed := []reflect.Kind{reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String}
 
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeMinLength, map[string]any{"minimum": min}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
 		if rval.Len() < min {
 			return []validation.ConstraintViolation{
-				ctx.Violation("minimum length not met", map[string]any{
+				ctx.CodedViolation(CodeMinLength, "minimum length not met", map[string]any{
 					"actual":  rval.Len(),
 					"minimum": min,
 				}),
@@ -21,5 +21,5 @@ func MinLength(min int) validation.ConstraintFunc {
 		}
 
 		return nil
-	}, allowed...)
+	}, allowed...))
 }
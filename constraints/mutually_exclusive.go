@@ -9,7 +9,11 @@ import (
 // MutuallyExclusive ...
 // TODO: Support maps.
 func MutuallyExclusive(fields ...string) validation.ConstraintFunc {
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeMutuallyExclusive, map[string]any{"fields": fields}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+		if !ctx.AnyFieldInMask(fields...) {
+			return nil
+		}
+
 		var nonEmpty []string
 		for _, field := range fields {
 			f := rval.FieldByName(field)
@@ -20,12 +24,12 @@ func MutuallyExclusive(fields ...string) validation.ConstraintFunc {
 
 		if len(nonEmpty) > 1 {
 			return []validation.ConstraintViolation{
-				ctx.Violation("fields are mutually exclusive", map[string]any{
+				ctx.CodedViolation(CodeMutuallyExclusive, "fields are mutually exclusive", map[string]any{
 					"fields": nonEmpty,
 				}),
 			}
 		}
 
 		return nil
-	}, reflect.Struct)
+	}, reflect.Struct))
 }
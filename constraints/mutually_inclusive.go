@@ -9,7 +9,11 @@ import (
 // MutuallyInclusive ...
 // TODO: Support maps.
 func MutuallyInclusive(fields ...string) validation.ConstraintFunc {
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeMutuallyInclusive, map[string]any{"fields": fields}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+		if !ctx.AnyFieldInMask(fields...) {
+			return nil
+		}
+
 		fieldNames := make([]string, 0, len(fields))
 
 		var nonEmpty []string
@@ -26,12 +30,12 @@ func MutuallyInclusive(fields ...string) validation.ConstraintFunc {
 
 		if len(nonEmpty) > 1 && len(nonEmpty) != len(fields) {
 			return []validation.ConstraintViolation{
-				ctx.Violation("fields are mutually inclusive", map[string]any{
+				ctx.CodedViolation(CodeMutuallyInclusive, "fields are mutually inclusive", map[string]any{
 					"fields": fieldNames,
 				}),
 			}
 		}
 
 		return nil
-	}, reflect.Struct)
+	}, reflect.Struct))
 }
@@ -3,13 +3,13 @@ package constraints
 import "github.com/seeruk/go-validation"
 
 // Nil ...
-var Nil validation.ConstraintFunc = func(ctx validation.Context) []validation.ConstraintViolation {
+var Nil = describe(CodeNil, nil, func(ctx validation.Context) []validation.ConstraintViolation {
 	rval := ctx.Value().Node
 	if validation.IsNillable(rval) && !rval.IsNil() {
 		return []validation.ConstraintViolation{
-			ctx.Violation("value must be nil", nil),
+			ctx.CodedViolation(CodeNil, "value must be nil", nil),
 		}
 	}
 
 	return nil
-}
+})
@@ -19,7 +19,7 @@ func NoError[V any](fn func(V) error, message string) validation.ConstraintFunc
 		if !ok {
 			var vt V
 			return []validation.ConstraintViolation{
-				ctx.Violation("value does not match expected type", map[string]any{
+				ctx.CodedViolation(CodeType, "value does not match expected type", map[string]any{
 					"expected": fmt.Sprintf("%T", vt),
 					"actual":   fmt.Sprintf("%T", rval.Interface()),
 				}),
@@ -28,7 +28,7 @@ func NoError[V any](fn func(V) error, message string) validation.ConstraintFunc
 
 		if err := fn(v); err != nil {
 			return []validation.ConstraintViolation{
-				ctx.Violation(message, map[string]any{
+				ctx.CodedViolation(CodeNoError, message, map[string]any{
 					"error": err.Error(),
 				}),
 			}
@@ -12,7 +12,7 @@ func NoneOf[T any](disallowed ...T) validation.ConstraintFunc {
 		panic("constraints: NoneOf must be given at least 2 disallowed values")
 	}
 
-	return func(ctx validation.Context) []validation.ConstraintViolation {
+	return describe(CodeNoneOf, map[string]any{"disallowed": disallowed}, func(ctx validation.Context) []validation.ConstraintViolation {
 		rval := validation.UnwrapValue(ctx.Value().Node)
 		if validation.IsEmpty(rval) {
 			return nil
@@ -28,12 +28,12 @@ func NoneOf[T any](disallowed ...T) validation.ConstraintFunc {
 
 		if found {
 			return []validation.ConstraintViolation{
-				ctx.Violation("value must not be one of the disallowed values", map[string]any{
+				ctx.CodedViolation(CodeNoneOf, "value must not be one of the disallowed values", map[string]any{
 					"disallowed": disallowed,
 				}),
 			}
 		}
 
 		return nil
-	}
+	})
 }
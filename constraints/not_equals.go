@@ -8,7 +8,7 @@ import (
 
 // NotEquals ...
 func NotEquals(value any) validation.ConstraintFunc {
-	return func(ctx validation.Context) []validation.ConstraintViolation {
+	return describe(CodeNotEquals, map[string]any{"expected": value}, func(ctx validation.Context) []validation.ConstraintViolation {
 		rval := validation.UnwrapValue(ctx.Value().Node)
 		if validation.IsEmpty(rval) {
 			return nil
@@ -17,12 +17,12 @@ func NotEquals(value any) validation.ConstraintFunc {
 		if reflect.DeepEqual(rval.Interface(), value) {
 			return []validation.ConstraintViolation{
 				// TODO: Better message wording...
-				ctx.Violation("value must not equal expected value", map[string]any{
+				ctx.CodedViolation(CodeNotEquals, "value must not equal expected value", map[string]any{
 					"expected": value,
 				}),
 			}
 		}
 
 		return nil
-	}
+	})
 }
@@ -12,7 +12,7 @@ func OneOf[T any](allowed ...T) validation.ConstraintFunc {
 		panic("constraints: OneOf must be given at least 2 allowed values")
 	}
 
-	return func(ctx validation.Context) []validation.ConstraintViolation {
+	return describe(CodeOneOf, map[string]any{"allowed": allowed}, func(ctx validation.Context) []validation.ConstraintViolation {
 		rval := validation.UnwrapValue(ctx.Value().Node)
 		if validation.IsEmpty(rval) {
 			return nil
@@ -28,12 +28,12 @@ func OneOf[T any](allowed ...T) validation.ConstraintFunc {
 
 		if !found {
 			return []validation.ConstraintViolation{
-				ctx.Violation("value must be one of the allowed values", map[string]any{
+				ctx.CodedViolation(CodeOneOf, "value must be one of the allowed values", map[string]any{
 					"allowed": allowed,
 				}),
 			}
 		}
 
 		return nil
-	}
+	})
 }
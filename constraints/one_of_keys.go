@@ -13,7 +13,7 @@ func OneOfKeys[T any](keys ...T) validation.ConstraintFunc {
 		panic("constraints: OneOfKeys must be given at least 1 allowed value")
 	}
 
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeOneOfKeys, map[string]any{"keys": keys}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
 		// We don't want to be looping twice every time, so a map is made.
 		allowed := make(map[any]struct{}, len(keys))
 		for _, k := range keys {
@@ -32,12 +32,12 @@ func OneOfKeys[T any](keys ...T) validation.ConstraintFunc {
 
 		if len(unexpected) > 0 {
 			return []validation.ConstraintViolation{
-				ctx.Violation("key must be one of the allowed keys", map[string]any{
+				ctx.CodedViolation(CodeOneOfKeys, "key must be one of the allowed keys", map[string]any{
 					"unexpected": unexpected,
 				}),
 			}
 		}
 
 		return nil
-	}, reflect.Map)
+	}, reflect.Map))
 }
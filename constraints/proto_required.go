@@ -0,0 +1,31 @@
+package constraints
+
+import (
+	"reflect"
+
+	"github.com/seeruk/go-validation"
+)
+
+// ProtoRequired is like Required, but is aware of field presence in ProtoBuf messages validated
+// using validation.ProtoFields. Fields with presence (i.e. optional fields, fields in a oneof, and
+// message fields) that are set are never empty, even if they're set to their zero value. Other
+// fields are required to have a non-empty value, exactly like Required.
+var ProtoRequired = describe(CodeRequired, nil, func(ctx validation.Context) []validation.ConstraintViolation {
+	rval := ctx.Value().Node
+	for rval.IsValid() && rval.Kind() == reflect.Interface && !rval.IsNil() {
+		rval = rval.Elem()
+	}
+
+	// ProtoFields gives fields with presence as pointers, which are only nil if they're not set.
+	if rval.IsValid() && rval.Kind() == reflect.Pointer {
+		if !rval.IsNil() {
+			return nil
+		}
+	} else if !validation.IsEmpty(validation.UnwrapValue(rval)) {
+		return nil
+	}
+
+	return []validation.ConstraintViolation{
+		ctx.CodedViolation(CodeRequired, "a value is required", nil),
+	}
+})
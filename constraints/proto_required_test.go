@@ -0,0 +1,54 @@
+package constraints
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestProtoRequired(t *testing.T) {
+	t.Run("should not return a violation if a field with presence is set to its zero value", func(t *testing.T) {
+		field := &descriptorpb.FieldDescriptorProto{
+			Number:  proto.Int32(0),
+			Options: &descriptorpb.FieldOptions{},
+		}
+
+		violations := validation.Validate(field, validation.ProtoFields{
+			"number":  ProtoRequired,
+			"options": ProtoRequired,
+		})
+
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should return a violation if a field with presence is not set", func(t *testing.T) {
+		violations := validation.Validate(&descriptorpb.FieldDescriptorProto{}, validation.ProtoFields{
+			"number":  ProtoRequired,
+			"options": ProtoRequired,
+		})
+
+		if assert.Len(t, violations, 2) {
+			assert.Equal(t, CodeRequired, violations[0].Code)
+			assert.Equal(t, CodeRequired, violations[1].Code)
+		}
+	})
+
+	t.Run("should require other values to not be empty", func(t *testing.T) {
+		assert.Empty(t, ProtoRequired(validation.NewContext("test")))
+		assert.Empty(t, ProtoRequired(validation.NewContext([]any{"test"})))
+		assert.NotEmpty(t, ProtoRequired(validation.NewContext("")))
+		assert.NotEmpty(t, ProtoRequired(validation.NewContext([]any(nil))))
+		assert.NotEmpty(t, ProtoRequired(validation.NewContext(nil)))
+	})
+
+	t.Run("should require repeated fields to not be empty", func(t *testing.T) {
+		violations := validation.Validate(&descriptorpb.DescriptorProto{}, validation.ProtoFields{
+			"field": ProtoRequired,
+		})
+
+		assert.Len(t, violations, 1)
+	})
+}
@@ -9,10 +9,10 @@ import (
 
 // Regexp ...
 func Regexp(pattern *regexp.Regexp) validation.ConstraintFunc {
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeRegexp, map[string]any{"regexp": pattern.String()}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
 		if !pattern.MatchString(rval.String()) {
 			return []validation.ConstraintViolation{
-				ctx.Violation("value must match regular expression", map[string]any{
+				ctx.CodedViolation(CodeRegexp, "value must match regular expression", map[string]any{
 					// TODO: Include actual value?
 					"regexp": pattern.String(),
 				}),
@@ -20,5 +20,5 @@ func Regexp(pattern *regexp.Regexp) validation.ConstraintFunc {
 		}
 
 		return nil
-	}, reflect.String)
+	}, reflect.String))
 }
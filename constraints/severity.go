@@ -0,0 +1,31 @@
+package constraints
+
+import "github.com/seeruk/go-validation"
+
+// AsWarning downgrades any violations produced by the given constraint to warnings, so that they
+// flag a problem with a value without making it invalid (e.g. for deprecated fields).
+func AsWarning(c validation.Constraint) validation.ConstraintFunc {
+	return withSeverity(c, validation.SeverityWarning)
+}
+
+// AsInfo downgrades any violations produced by the given constraint to info, so that they provide
+// information about a value without making it invalid.
+func AsInfo(c validation.Constraint) validation.ConstraintFunc {
+	return withSeverity(c, validation.SeverityInfo)
+}
+
+// withSeverity sets the severity of any violations produced by the given constraint.
+func withSeverity(c validation.Constraint, severity validation.Severity) validation.ConstraintFunc {
+	return validation.WrapFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+		// Downgraded violations shouldn't stop validation, but they'll look like errors to the
+		// constraint we're wrapping, so it mustn't fail fast on them.
+		ctx.FailFast = false
+
+		violations := c.Violations(ctx)
+		for i := range violations {
+			violations[i].Severity = severity
+		}
+
+		return violations
+	}, c)
+}
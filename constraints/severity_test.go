@@ -0,0 +1,45 @@
+package constraints
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsWarning(t *testing.T) {
+	t.Run("should return no violations if the constraint argument has no violations", func(t *testing.T) {
+		violations := AsWarning(Required)(validation.NewContext("test"))
+		assert.Len(t, violations, 0)
+	})
+
+	t.Run("should downgrade violations to warnings", func(t *testing.T) {
+		violations := AsWarning(Required)(validation.NewContext(""))
+		require.Len(t, violations, 1)
+		assert.Equal(t, validation.SeverityWarning, violations[0].Severity)
+	})
+
+	t.Run("should downgrade all violations from nested constraints", func(t *testing.T) {
+		ctx := validation.NewContext([]string{"", ""})
+		ctx.FailFast = true
+
+		violations := AsWarning(validation.Elements{Required})(ctx)
+		require.Len(t, violations, 2)
+		assert.Equal(t, validation.SeverityWarning, violations[0].Severity)
+		assert.Equal(t, validation.SeverityWarning, violations[1].Severity)
+	})
+
+	t.Run("should not make the value invalid", func(t *testing.T) {
+		err := validation.ValidateErr("", AsWarning(Required))
+		assert.NoError(t, err)
+	})
+}
+
+func TestAsInfo(t *testing.T) {
+	t.Run("should downgrade violations to info", func(t *testing.T) {
+		violations := AsInfo(Required)(validation.NewContext(""))
+		require.Len(t, violations, 1)
+		assert.Equal(t, validation.SeverityInfo, violations[0].Severity)
+	})
+}
@@ -0,0 +1,32 @@
+package constraints
+
+import "github.com/seeruk/go-validation"
+
+// Template is exactly like Details, except the message is a template (see
+// validation.RenderTemplate) that can reference the details of the original violation, as well as
+// any given details, e.g. "must be at least {minimum} characters". The given details are added to
+// the details of the original violation, replacing any with the same key, and the template is kept
+// on the violation so that clients can render it again.
+func Template(c validation.Constraint, template string, details ...any) validation.ConstraintFunc {
+	return validation.DescribeFuncAs(func(ctx validation.Context) []validation.ConstraintViolation {
+		violations := c.Violations(ctx)
+		if len(violations) == 0 {
+			return nil
+		}
+
+		merged := detailsMap(details...)
+		for key, value := range violations[0].Details {
+			if _, ok := merged[key]; !ok {
+				merged[key] = value
+			}
+		}
+
+		// The code, severity, and group of the original violation still apply, just like Details.
+		violation := ctx.TemplateViolation(violations[0].Code, template, merged)
+		violation.Severity = violations[0].Severity
+		violation.Group = violations[0].Group
+		violation.Custom = true
+
+		return []validation.ConstraintViolation{violation}
+	}, c)
+}
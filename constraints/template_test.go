@@ -0,0 +1,38 @@
+package constraints
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate(t *testing.T) {
+	t.Run("should return no violations if the constraint argument has no violations", func(t *testing.T) {
+		violations := Template(MinLength(3), "should not happen")(validation.NewContext("abc"))
+		assert.Len(t, violations, 0, "should not return any violations")
+	})
+
+	t.Run("should render the message using the details of the original violation and the given details", func(t *testing.T) {
+		violations := Template(MinLength(3), "must be at least {minimum} characters, not {actual} ({hint})", "hint", "try again")(validation.NewContext("ab"))
+		assert.Len(t, violations, 1, "should return a single violation")
+		assert.Equal(t, CodeMinLength, violations[0].Code, "should have the code of the original violation")
+		assert.Equal(t, "must be at least 3 characters, not 2 (try again)", violations[0].Message)
+		assert.Equal(t, "must be at least {minimum} characters, not {actual} ({hint})", violations[0].Template)
+	})
+
+	t.Run("should replace the original violation's details with the given details", func(t *testing.T) {
+		violations := Template(Equals("test"), "must be {expected}", "expected", "something")(validation.NewContext("not test"))
+		assert.Len(t, violations, 1, "should return a single violation")
+		assert.Equal(t, "must be something", violations[0].Message)
+		assert.Equal(t, map[string]any{
+			"expected": "something",
+		}, violations[0].Details, "should have the given details")
+	})
+
+	t.Run("should keep the severity of the constraint argument's violations", func(t *testing.T) {
+		violations := Template(AsWarning(Equals("test")), "should happen")(validation.NewContext("not test"))
+		assert.Len(t, violations, 1, "should return a single violation")
+		assert.Equal(t, validation.SeverityWarning, violations[0].Severity)
+	})
+}
@@ -9,12 +9,12 @@ import (
 
 // TimeAfter ...
 func TimeAfter(after time.Time) validation.ConstraintFunc {
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeTimeAfter, map[string]any{"time": after}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
 		switch v := rval.Interface().(type) {
 		case time.Time:
 			if !v.After(after) {
 				return []validation.ConstraintViolation{
-					ctx.Violation("value must be after time", map[string]any{
+					ctx.CodedViolation(CodeTimeAfter, "value must be after time", map[string]any{
 						// TODO: after_time and actual_time?
 						"time": after.Format(time.RFC3339),
 					}),
@@ -23,5 +23,5 @@ func TimeAfter(after time.Time) validation.ConstraintFunc {
 		}
 
 		return nil
-	}, reflect.Struct)
+	}, reflect.Struct))
 }
@@ -9,12 +9,12 @@ import (
 
 // TimeBefore ...
 func TimeBefore(before time.Time) validation.ConstraintFunc {
-	return ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
+	return describe(CodeTimeBefore, map[string]any{"time": before}, ValueFunc(func(ctx validation.Context, rval reflect.Value) []validation.ConstraintViolation {
 		switch v := rval.Interface().(type) {
 		case time.Time:
 			if !v.Before(before) {
 				return []validation.ConstraintViolation{
-					ctx.Violation("value must be before time", map[string]any{
+					ctx.CodedViolation(CodeTimeBefore, "value must be before time", map[string]any{
 						// TODO: before_time and actual_time?
 						"time": before.Format(time.RFC3339),
 					}),
@@ -23,5 +23,5 @@ func TimeBefore(before time.Time) validation.ConstraintFunc {
 		}
 
 		return nil
-	}, reflect.Struct)
+	}, reflect.Struct))
 }
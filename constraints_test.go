@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -50,6 +51,83 @@ func TestConstraints(t *testing.T) {
 			require.Equal(t, ".text", violations[1].Path)
 		}
 	})
+
+	t.Run("should stop at the first violation when failing fast", func(t *testing.T) {
+		testConstraint := &TestConstraint{}
+
+		ctx := NewContext((*TestSubject)(nil))
+		ctx.FailFast = true
+
+		violations := ValidateContext(ctx, Constraints{
+			testConstraint,
+			testConstraint,
+			testConstraint,
+		})
+
+		assert.Equal(t, 1, testConstraint.Calls)
+		assert.Len(t, violations, 1)
+	})
+
+	t.Run("should skip remaining constraints on a path once one fails when bailing per path", func(t *testing.T) {
+		passing := &TestConstraint{NoViolation: true}
+		failing := &TestConstraint{}
+		skipped := &TestConstraint{}
+
+		ctx := NewContext(TestSubject{})
+		ctx.BailPerPath = true
+
+		violations := ValidateContext(ctx, Constraints{
+			passing,
+			failing,
+			skipped,
+		})
+
+		assert.Equal(t, 1, passing.Calls)
+		assert.Equal(t, 1, failing.Calls)
+		assert.Equal(t, 0, skipped.Calls)
+		assert.Len(t, violations, 1)
+	})
+
+	t.Run("should only bail on the path that failed when bailing per path", func(t *testing.T) {
+		ctx := NewContext(TestSubject{})
+		ctx.BailPerPath = true
+
+		violations := ValidateContext(ctx, Fields{
+			"Text":   Constraints{&TestConstraint{}, &TestConstraint{}},
+			"Number": Constraints{&TestConstraint{}, &TestConstraint{}},
+		})
+
+		require.Len(t, violations, 2)
+		assert.Equal(t, ".number", violations[0].Path)
+		assert.Equal(t, ".text", violations[1].Path)
+	})
+
+	t.Run("should not bail when violations are on a different path when bailing per path", func(t *testing.T) {
+		testConstraint := &TestConstraint{}
+
+		ctx := NewContext(TestSubject{})
+		ctx.BailPerPath = true
+
+		violations := ValidateContext(ctx, Constraints{
+			Fields{"Text": testConstraint},
+			testConstraint,
+		})
+
+		assert.Equal(t, 2, testConstraint.Calls)
+		assert.Len(t, violations, 2)
+	})
+
+	t.Run("should stop running constraints once cancelled", func(t *testing.T) {
+		ctx, testConstraint := cancellingContext(t, (*TestSubject)(nil))
+
+		Constraints{
+			testConstraint,
+			testConstraint,
+			testConstraint,
+		}.Violations(ctx)
+
+		assert.Equal(t, 1, testConstraint.Calls)
+	})
 }
 
 func TestElements(t *testing.T) {
@@ -243,6 +321,8 @@ func TestElements(t *testing.T) {
 			require.Len(t, violations, 2)
 			assert.Equal(t, ".[0]", violations[0].Path)
 			assert.Equal(t, ".[1]", violations[1].Path)
+			assert.Equal(t, []PathSegment{IndexSegment(0)}, violations[0].Segments)
+			assert.Equal(t, []PathSegment{IndexSegment(1)}, violations[1].Segments)
 		})
 
 		t.Run("against a map", func(t *testing.T) {
@@ -257,6 +337,7 @@ func TestElements(t *testing.T) {
 
 			require.Len(t, violations, 1)
 			assert.Equal(t, ".Hello", violations[0].Path)
+			assert.Equal(t, []PathSegment{KeySegment("Hello")}, violations[0].Segments)
 		})
 
 		t.Run("against a map with a nil key", func(t *testing.T) {
@@ -298,6 +379,53 @@ func TestElements(t *testing.T) {
 
 		assert.Len(t, violations, 1)
 	})
+
+	t.Run("should stop at the first violation when failing fast", func(t *testing.T) {
+		testConstraint := &TestConstraint{}
+
+		ctx := NewContext([]string{"Hello", "World"})
+		ctx.FailFast = true
+
+		violations := ValidateContext(ctx, Elements{
+			testConstraint,
+			testConstraint,
+		})
+
+		assert.Equal(t, 1, testConstraint.Calls)
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".[0]", violations[0].Path)
+	})
+
+	t.Run("should bail per element when bailing per path", func(t *testing.T) {
+		testConstraint := &TestConstraint{}
+
+		ctx := NewContext([]string{"Hello", "World"})
+		ctx.BailPerPath = true
+
+		violations := ValidateContext(ctx, Elements{
+			testConstraint,
+			testConstraint,
+		})
+
+		assert.Equal(t, 2, testConstraint.Calls)
+		require.Len(t, violations, 2)
+		assert.Equal(t, ".[0]", violations[0].Path)
+		assert.Equal(t, ".[1]", violations[1].Path)
+	})
+
+	t.Run("should stop descending once cancelled", func(t *testing.T) {
+		t.Run("against a map", func(t *testing.T) {
+			ctx, testConstraint := cancellingContext(t, map[string]int{"a": 1, "b": 2, "c": 3})
+			Elements{testConstraint}.Violations(ctx)
+			assert.Equal(t, 1, testConstraint.Calls)
+		})
+
+		t.Run("against a slice", func(t *testing.T) {
+			ctx, testConstraint := cancellingContext(t, []int{1, 2, 3})
+			Elements{testConstraint}.Violations(ctx)
+			assert.Equal(t, 1, testConstraint.Calls)
+		})
+	})
 }
 
 func TestFields(t *testing.T) {
@@ -387,6 +515,17 @@ func TestFields(t *testing.T) {
 
 		require.Len(t, violations, 1)
 		assert.Equal(t, ".Foo", violations[0].Path)
+		assert.Equal(t, []PathSegment{FieldSegment("Foo", "Foo")}, violations[0].Segments)
+	})
+
+	t.Run("should include both the Go name and output name of fields in path segments", func(t *testing.T) {
+		violations := Validate(TestSubject{}, Fields{
+			"Text": &TestConstraint{},
+		})
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".text", violations[0].Path)
+		assert.Equal(t, []PathSegment{FieldSegment("Text", "text")}, violations[0].Segments)
 	})
 
 	t.Run("should return violations if the given type is not allowed, and the value is not empty", func(t *testing.T) {
@@ -400,6 +539,18 @@ func TestFields(t *testing.T) {
 
 		assert.Len(t, violations, 1)
 	})
+
+	t.Run("should stop descending once cancelled", func(t *testing.T) {
+		ctx, testConstraint := cancellingContext(t, fieldsTester{})
+
+		Fields{
+			"Foo": testConstraint,
+			"Bar": testConstraint,
+			"Baz": testConstraint,
+		}.Violations(ctx)
+
+		assert.Equal(t, 1, testConstraint.Calls)
+	})
 }
 
 func TestKeys(t *testing.T) {
@@ -501,6 +652,8 @@ func TestKeys(t *testing.T) {
 
 		require.Len(t, violations, 1)
 		assert.Equal(t, ".Foo", violations[0].Path)
+		assert.Equal(t, []PathSegment{KeySegment("Foo")}, violations[0].Segments)
+		assert.Equal(t, PathKindKey, violations[0].PathKind)
 	})
 
 	t.Run("should update the path, even with a nil key", func(t *testing.T) {
@@ -528,6 +681,12 @@ func TestKeys(t *testing.T) {
 
 		assert.Len(t, violations, 1)
 	})
+
+	t.Run("should stop descending once cancelled", func(t *testing.T) {
+		ctx, testConstraint := cancellingContext(t, map[string]int{"a": 1, "b": 2, "c": 3})
+		Keys{testConstraint}.Violations(ctx)
+		assert.Equal(t, 1, testConstraint.Calls)
+	})
 }
 
 func TestLazy(t *testing.T) {
@@ -752,6 +911,17 @@ func TestMap(t *testing.T) {
 
 		require.Len(t, violations, 1)
 		assert.Equal(t, ".Foo", violations[0].Path)
+		assert.Equal(t, []PathSegment{KeySegment("Foo")}, violations[0].Segments)
+	})
+
+	t.Run("should retain the original type of keys in path segments", func(t *testing.T) {
+		violations := Validate(map[int]string{12: "hello"}, Map{
+			12: &TestConstraint{},
+		})
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".12", violations[0].Path)
+		assert.Equal(t, []PathSegment{KeySegment(12)}, violations[0].Segments)
 	})
 
 	t.Run("should return violations if the given type is not allowed, and the value is not empty", func(t *testing.T) {
@@ -775,6 +945,18 @@ func TestMap(t *testing.T) {
 
 		assert.Len(t, violations, 1)
 	})
+
+	t.Run("should stop descending once cancelled", func(t *testing.T) {
+		ctx, testConstraint := cancellingContext(t, map[string]int{"a": 1, "b": 2, "c": 3})
+
+		Map{
+			"a": testConstraint,
+			"b": testConstraint,
+			"c": testConstraint,
+		}.Violations(ctx)
+
+		assert.Equal(t, 1, testConstraint.Calls)
+	})
 }
 
 func TestWhen(t *testing.T) {
@@ -877,6 +1059,47 @@ func TestWhenFn(t *testing.T) {
 	})
 }
 
+func TestSequence(t *testing.T) {
+	t.Run("should run all constraints if none return violations", func(t *testing.T) {
+		testConstraint := &TestConstraint{NoViolation: true}
+
+		violations := Validate((*TestSubject)(nil), Sequence(
+			testConstraint,
+			testConstraint,
+			testConstraint,
+		))
+
+		assert.Equal(t, 3, testConstraint.Calls)
+		assert.Len(t, violations, 0)
+	})
+
+	t.Run("should stop at the first constraint that returns violations", func(t *testing.T) {
+		passing := &TestConstraint{NoViolation: true}
+		failing := &TestConstraint{}
+		skipped := &TestConstraint{}
+
+		violations := Validate((*TestSubject)(nil), Sequence(
+			passing,
+			failing,
+			skipped,
+		))
+
+		assert.Equal(t, 1, passing.Calls)
+		assert.Equal(t, 1, failing.Calls)
+		assert.Equal(t, 0, skipped.Calls)
+		assert.Len(t, violations, 1)
+	})
+
+	t.Run("should return all violations from the failing constraint", func(t *testing.T) {
+		violations := Validate((*TestSubject)(nil), Sequence(
+			Constraints{&TestConstraint{}, &TestConstraint{}},
+			&TestConstraint{},
+		))
+
+		assert.Len(t, violations, 2)
+	})
+}
+
 type TestSubject struct {
 	Text   string `validation:"text"`
 	Number int    `validation:"number"`
@@ -885,11 +1108,16 @@ type TestSubject struct {
 type TestConstraint struct {
 	Calls       int
 	NoViolation bool
+	OnCall      func()
 }
 
 func (c *TestConstraint) Violations(ctx Context) []ConstraintViolation {
 	c.Calls++
 
+	if c.OnCall != nil {
+		c.OnCall()
+	}
+
 	var violations []ConstraintViolation
 	if !c.NoViolation {
 		violations = append(violations, ctx.Violation("test violations", nil))
@@ -897,3 +1125,12 @@ func (c *TestConstraint) Violations(ctx Context) []ConstraintViolation {
 
 	return violations
 }
+
+// cancellingContext returns a Context for the given value, and a TestConstraint that cancels that
+// Context the first time it is called.
+func cancellingContext(t *testing.T, value any) (Context, *TestConstraint) {
+	stdCtx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	return NewContext(value).WithContext(stdCtx), &TestConstraint{OnCall: cancel}
+}
@@ -0,0 +1,223 @@
+package validation
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Validatable is implemented by types that define their own constraints. When a Context has Deep
+// set, the constraints of any Validatable value reached while validating are applied automatically.
+type Validatable interface {
+	Constraints() Constraint
+}
+
+// validatableType is kept on its own here because it won't change.
+var validatableType = reflect.TypeOf((*Validatable)(nil)).Elem()
+
+// WithDeep returns an Option that enables deep validation, see Context.Deep.
+func WithDeep() Option {
+	return func(ctx *Context) {
+		ctx.Deep = true
+	}
+}
+
+// deepConstraint walks the value on the Context, applying the constraints of every Validatable
+// value it reaches via struct fields, array and slice elements, map values, and pointers. Pointers,
+// maps, and slices aren't followed if they've already been followed to reach the current value, so
+// cyclic values don't cause infinite recursion.
+type deepConstraint struct{}
+
+// Violations ...
+func (deepConstraint) Violations(ctx Context) []ConstraintViolation {
+	return walkValidatables(ctx, nil)
+}
+
+// pointerKey identifies a value reached via a pointer, or the data of a map or slice, used to
+// detect cycles. Slices also need their length, as a slice shares its pointer with its prefixes.
+type pointerKey struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+// followPointer returns the given ancestors with the given key added, and true, or false if the
+// key is already one of the ancestors.
+func followPointer(ancestors []pointerKey, key pointerKey) ([]pointerKey, bool) {
+	for _, ancestor := range ancestors {
+		if ancestor == key {
+			return ancestors, false
+		}
+	}
+
+	return append(ancestors[:len(ancestors):len(ancestors)], key), true
+}
+
+// walkValidatables applies the constraints of the value on the given Context if it's Validatable,
+// then descends into any values it contains. The given ancestors are the pointers, maps, and slices
+// that have been followed to reach this value.
+func walkValidatables(ctx Context, ancestors []pointerKey) []ConstraintViolation {
+	if ctx.outsideMask {
+		return nil
+	}
+
+	rval := ctx.Value().Node
+	for rval.Kind() == reflect.Interface && !rval.IsNil() {
+		// The constraints of the value held by an interface should be applied, e.g. if a field of
+		// type any holds a Validatable value.
+		rval = rval.Elem()
+	}
+
+	if !rval.IsValid() || (IsNillable(rval) && rval.IsNil()) || !mayContainValidatable(rval.Type()) {
+		return nil
+	}
+
+	var violations []ConstraintViolation
+	if validatable, ok := asValidatable(rval); ok {
+		violations = append(violations, ctx.apply(validatable.Constraints())...)
+	}
+
+	for rval.Kind() == reflect.Ptr || rval.Kind() == reflect.Interface {
+		if rval.IsNil() {
+			return ctx.limit(violations)
+		}
+
+		if rval.Kind() == reflect.Ptr {
+			var ok bool
+			if ancestors, ok = followPointer(ancestors, pointerKey{typ: rval.Type(), ptr: rval.Pointer()}); !ok {
+				return ctx.limit(violations)
+			}
+		}
+
+		rval = rval.Elem()
+	}
+
+	if rval.Kind() == reflect.Map || rval.Kind() == reflect.Slice {
+		// Maps and slices may contain themselves too, e.g. a map[string]any holding itself.
+		var ok bool
+		if ancestors, ok = followPointer(ancestors, pointerKey{typ: rval.Type(), ptr: rval.Pointer(), len: rval.Len()}); !ok {
+			return ctx.limit(violations)
+		}
+	}
+
+	switch rval.Kind() {
+	case reflect.Struct:
+		for _, fi := range cachedStructInfo(rval.Type()).own {
+			if ctx.shouldStop(violations) {
+				break
+			}
+
+			ctx := ctx.WithField(fi.field.Name, fi.name(ctx.StructTag), rval.Field(fi.field.Index[0]))
+			violations = append(violations, walkValidatables(ctx, ancestors)...)
+		}
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < rval.Len() && !ctx.shouldStop(violations); i++ {
+			ctx := ctx.WithIndex(i, rval.Index(i))
+			violations = append(violations, walkValidatables(ctx, ancestors)...)
+		}
+	case reflect.Map:
+		keys := rval.MapKeys()
+		sortMapKeys(keys)
+
+		for i := 0; i < len(keys) && !ctx.shouldStop(violations); i++ {
+			ctx := ctx.WithKey(keys[i], rval.MapIndex(keys[i]))
+			violations = append(violations, walkValidatables(ctx, ancestors)...)
+		}
+	}
+
+	return ctx.limit(violations)
+}
+
+// asValidatable returns the given value as a Validatable, and true, or false if it isn't one. The
+// address of the value is used if it has to be, so that types implementing Validatable with a
+// pointer receiver are found when they're held by value in a struct field or element.
+func asValidatable(rval reflect.Value) (Validatable, bool) {
+	if rval.CanInterface() && rval.Type().Implements(validatableType) {
+		return rval.Interface().(Validatable), true
+	}
+
+	if rval.CanAddr() && rval.Addr().CanInterface() && rval.Addr().Type().Implements(validatableType) {
+		return rval.Addr().Interface().(Validatable), true
+	}
+
+	return nil, false
+}
+
+// mayContainValidatableCache holds whether each type may contain a Validatable value, keyed by its
+// reflect.Type.
+var mayContainValidatableCache sync.Map
+
+// mayContainValidatable returns true if a value of the given type either is, or may contain, a
+// Validatable value. This lets deep validation skip over values that can't contain one, like large
+// slices of bytes.
+func mayContainValidatable(typ reflect.Type) bool {
+	if may, ok := mayContainValidatableCache.Load(typ); ok {
+		return may.(bool)
+	}
+
+	may := reachesValidatable(typ, make(map[reflect.Type]bool))
+	mayContainValidatableCache.Store(typ, may)
+
+	return may
+}
+
+// reachesValidatable returns true if any type reachable from the given type, via exported fields,
+// elements, map values, or pointers, is Validatable (or a pointer to it is), or is an interface
+// (which may hold anything).
+func reachesValidatable(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[typ] {
+		return false
+	}
+
+	seen[typ] = true
+
+	if typ.Kind() == reflect.Interface || typ.Implements(validatableType) || reflect.PointerTo(typ).Implements(validatableType) {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.Array, reflect.Map, reflect.Ptr, reflect.Slice:
+		return reachesValidatable(typ.Elem(), seen)
+	case reflect.Struct:
+		for _, fi := range cachedStructInfo(typ).own {
+			if reachesValidatable(fi.field.Type, seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// uniqueViolations removes any duplicate violations from the given violations, keeping the first
+// of each. In deep mode, a Validatable value's constraints may also have been applied explicitly,
+// so the same violation can be produced twice.
+func uniqueViolations(violations []ConstraintViolation) []ConstraintViolation {
+	unique := violations[:0]
+
+	// Only violations with the same path can be duplicates, so we keep track of where the unique
+	// violations for each path are.
+	byPath := make(map[string][]int)
+
+	for _, violation := range violations {
+		var duplicate bool
+		for _, i := range byPath[violation.Path] {
+			existing := unique[i]
+			if existing.PathKind == violation.PathKind &&
+				existing.Severity == violation.Severity &&
+				existing.Code == violation.Code &&
+				existing.Group == violation.Group &&
+				existing.Message == violation.Message &&
+				reflect.DeepEqual(existing.Details, violation.Details) {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			byPath[violation.Path] = append(byPath[violation.Path], len(unique))
+			unique = append(unique, violation)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,165 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+)
+
+type deepChild struct {
+	Name string `validation:"name"`
+}
+
+func (c deepChild) Constraints() validation.Constraint {
+	return validation.Fields{
+		"Name": constraints.Required,
+	}
+}
+
+type deepParent struct {
+	Title    string               `validation:"title"`
+	Child    deepChild            `validation:"child"`
+	ChildPtr *deepChild           `validation:"child_ptr"`
+	Children []deepChild          `validation:"children"`
+	ChildMap map[string]deepChild `validation:"child_map"`
+	KeyMap   map[deepChild]string `validation:"key_map"`
+	Any      any                  `validation:"any"`
+	Next     *deepParent          `validation:"next"`
+	Bytes    []byte               `validation:"bytes"`
+}
+
+func (p *deepParent) Constraints() validation.Constraint {
+	return validation.Fields{
+		"Title": constraints.Required,
+	}
+}
+
+type deepPointerChild struct {
+	Name string `validation:"name"`
+}
+
+func (c *deepPointerChild) Constraints() validation.Constraint {
+	return validation.Fields{
+		"Name": constraints.Required,
+	}
+}
+
+type deepPointerParent struct {
+	Child    deepPointerChild    `validation:"child"`
+	Children []deepPointerChild  `validation:"children"`
+	Array    [1]deepPointerChild `validation:"array"`
+}
+
+func deepPaths(violations []validation.ConstraintViolation) []string {
+	var paths []string
+	for _, violation := range violations {
+		paths = append(paths, violation.Path)
+	}
+
+	return paths
+}
+
+func TestValidate_Deep(t *testing.T) {
+	t.Run("should not apply nested constraints if not enabled", func(t *testing.T) {
+		violations := validation.Validate(&deepParent{Title: "hello", ChildPtr: &deepChild{}})
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should apply the constraints of the root value", func(t *testing.T) {
+		ctx := validation.NewContext(&deepParent{}, validation.WithDeep())
+
+		violations := validation.ValidateContext(ctx)
+		assert.Equal(t, []string{".child.name", ".title"}, deepPaths(violations))
+	})
+
+	t.Run("should apply nested constraints reached via fields, elements, map values, and pointers", func(t *testing.T) {
+		ctx := validation.NewContext(&deepParent{
+			Title:    "hello",
+			Child:    deepChild{Name: "valid"},
+			ChildPtr: &deepChild{},
+			Children: []deepChild{{Name: "valid"}, {}},
+			ChildMap: map[string]deepChild{"foo": {}},
+			KeyMap:   map[deepChild]string{{}: "keys aren't walked"},
+			Any:      deepChild{},
+			Next:     &deepParent{Child: deepChild{Name: "valid"}},
+		}, validation.WithDeep())
+
+		violations := validation.ValidateContext(ctx)
+		assert.Equal(t, []string{
+			".any.name",
+			".child_map.foo.name",
+			".child_ptr.name",
+			".children.[1].name",
+			".next.title",
+		}, deepPaths(violations))
+	})
+
+	t.Run("should apply explicit constraints too, without duplicating violations", func(t *testing.T) {
+		ctx := validation.NewContext(&deepParent{
+			ChildPtr: &deepChild{},
+		}, validation.WithDeep())
+
+		violations := validation.ValidateContext(ctx, validation.Fields{
+			"ChildPtr": deepChild{}.Constraints(),
+			"Bytes":    constraints.Required,
+		})
+
+		assert.Equal(t, []string{".bytes", ".child.name", ".child_ptr.name", ".title"}, deepPaths(violations))
+	})
+
+	t.Run("should not recurse infinitely into cyclic values", func(t *testing.T) {
+		parent := &deepParent{Title: "hello", Child: deepChild{Name: "valid"}}
+		parent.Next = parent
+
+		ctx := validation.NewContext(parent, validation.WithDeep())
+
+		violations := validation.ValidateContext(ctx)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should not recurse infinitely into maps that contain themselves", func(t *testing.T) {
+		values := map[string]any{"child": &deepChild{}}
+		values["self"] = values
+
+		ctx := validation.NewContext(values, validation.WithDeep())
+
+		violations := validation.ValidateContext(ctx)
+		assert.Equal(t, []string{".child.name"}, deepPaths(violations))
+	})
+
+	t.Run("should not recurse infinitely into slices that contain themselves", func(t *testing.T) {
+		values := []any{&deepChild{}, nil}
+		values[1] = values
+
+		ctx := validation.NewContext(values, validation.WithDeep())
+
+		violations := validation.ValidateContext(ctx)
+		assert.Equal(t, []string{".[0].name"}, deepPaths(violations))
+	})
+
+	t.Run("should apply the constraints of values with pointer receivers held by value", func(t *testing.T) {
+		parent := &deepPointerParent{Children: []deepPointerChild{{}}}
+
+		ctx := validation.NewContext(parent, validation.WithDeep())
+
+		violations := validation.ValidateContext(ctx)
+		assert.Equal(t, []string{".array.[0].name", ".child.name", ".children.[0].name"}, deepPaths(violations))
+	})
+
+	t.Run("should be enabled by CreateValidateFunc options", func(t *testing.T) {
+		validate := validation.CreateValidateFunc("json", validation.WithDeep())
+
+		violations := validate(&deepParent{Title: "hello", ChildPtr: &deepChild{}})
+		assert.Equal(t, []string{".Child.Name", ".ChildPtr.Name"}, deepPaths(violations))
+	})
+
+	t.Run("should stop after the first violation when failing fast", func(t *testing.T) {
+		ctx := validation.NewContext(&deepParent{ChildPtr: &deepChild{}}, validation.WithDeep())
+		ctx.FailFast = true
+
+		violations := validation.ValidateContext(ctx)
+		assert.Len(t, violations, 1)
+	})
+}
@@ -0,0 +1,80 @@
+package validation
+
+import "slices"
+
+// Description describes the rule enforced by a constraint, by the code of the violations it
+// produces, and its parameters (e.g. the minimum of constraints.Min). Descriptions allow
+// constraints to be converted into other formats, like JSON Schema (see the jsonschema package).
+type Description struct {
+	Code   string
+	Params map[string]any
+}
+
+// Describer is implemented by constraints that can describe the rules they enforce without being
+// applied to a value, see Describe.
+type Describer interface {
+	Constraint
+
+	// Describe returns the descriptions of the rules enforced by the constraint itself, and the
+	// constraints whose rules it also enforces (e.g. those wrapped by Sequence), which should be
+	// described in turn. If both are empty, the constraint can't be described.
+	Describe() ([]Description, []Constraint)
+}
+
+// Describe returns the descriptions of the rules enforced by the given constraint, and true, or
+// false if any part of it can't be described, along with the descriptions of the parts that can.
+// Only Describers, and Constraints holding them, can be described. Constraints are never applied
+// while describing them, as there's no telling what they'd do.
+//
+// Structural constraints, like Fields, or Elements, aren't described either, as the constraints
+// they apply to other values should be described individually.
+func Describe(constraint Constraint) ([]Description, bool) {
+	switch c := constraint.(type) {
+	case Constraints:
+		var descriptions []Description
+		ok := len(c) > 0
+		for _, constraint := range c {
+			cDescriptions, cOK := Describe(constraint)
+			descriptions = append(descriptions, cDescriptions...)
+			ok = ok && cOK
+		}
+
+		return descriptions, ok
+	case Describer:
+		descriptions, constraints := c.Describe()
+		ok := len(descriptions) > 0 || len(constraints) > 0
+		for _, constraint := range constraints {
+			cDescriptions, cOK := Describe(constraint)
+			descriptions = append(descriptions, cDescriptions...)
+			ok = ok && cOK
+		}
+
+		return descriptions, ok
+	}
+
+	return nil, false
+}
+
+// DescribeFunc returns a ConstraintFunc that calls the given function, and that's described by the
+// given descriptions, see Describer. The function itself is never called to describe it.
+func DescribeFunc(fn ConstraintFunc, descriptions ...Description) ConstraintFunc {
+	return registerFunc(fn, &funcInfo{descriptions: descriptions})
+}
+
+// DescribeFuncAs returns a ConstraintFunc that calls the given function, and that's described by
+// the given constraints, exactly like DescribeFunc. This is for constraints that wrap others, like
+// Sequence, which enforce the rules of the constraints they wrap.
+func DescribeFuncAs(fn ConstraintFunc, constraints ...Constraint) ConstraintFunc {
+	return registerFunc(fn, &funcInfo{describedAs: constraints, wrapped: constraints})
+}
+
+// Describe returns the description of a ConstraintFunc returned by DescribeFunc or DescribeFuncAs.
+// Any other ConstraintFunc can't be described, so nothing is returned for it.
+func (c ConstraintFunc) Describe() ([]Description, []Constraint) {
+	info, ok := lookupFunc(c)
+	if !ok {
+		return nil, nil
+	}
+
+	return slices.Clone(info.descriptions), slices.Clone(info.describedAs)
+}
@@ -0,0 +1,77 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Run("should return the descriptions of described funcs without calling them", func(t *testing.T) {
+		var applied bool
+		fn := validation.DescribeFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			applied = true
+			return nil
+		}, validation.Description{Code: "custom", Params: map[string]any{"n": 1}})
+
+		descriptions, ok := validation.Describe(fn)
+
+		assert.True(t, ok)
+		assert.False(t, applied)
+		assert.Equal(t, []validation.Description{{Code: "custom", Params: map[string]any{"n": 1}}}, descriptions)
+	})
+
+	t.Run("should still call described funcs to validate values", func(t *testing.T) {
+		fn := validation.DescribeFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			return []validation.ConstraintViolation{ctx.Violation("test", nil)}
+		}, validation.Description{Code: "custom"})
+
+		violations := fn.Violations(validation.NewContext("test"))
+		assert.Len(t, violations, 1)
+	})
+
+	t.Run("should describe the constraints wrapped by other constraints", func(t *testing.T) {
+		descriptions, ok := validation.Describe(validation.Sequence(constraints.Required, constraints.MinLength(3)))
+
+		assert.True(t, ok)
+		assert.Equal(t, []validation.Description{
+			{Code: constraints.CodeRequired},
+			{Code: constraints.CodeMinLength, Params: map[string]any{"minimum": 3}},
+		}, descriptions)
+	})
+
+	t.Run("should not apply constraints that can't describe themselves", func(t *testing.T) {
+		var applied bool
+		custom := validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			applied = true
+			return nil
+		})
+
+		descriptions, ok := validation.Describe(validation.Sequence(constraints.Required, custom))
+
+		assert.False(t, ok)
+		assert.False(t, applied)
+		assert.Equal(t, []validation.Description{{Code: constraints.CodeRequired}}, descriptions)
+	})
+
+	t.Run("should return false for funcs that aren't described", func(t *testing.T) {
+		descriptions, ok := validation.Describe(validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			return nil
+		}))
+
+		assert.False(t, ok)
+		assert.Empty(t, descriptions)
+	})
+
+	t.Run("should not describe structural constraints", func(t *testing.T) {
+		_, ok := validation.Describe(validation.Fields{"Name": constraints.Required})
+		assert.False(t, ok)
+	})
+
+	t.Run("should not describe constraints in groups", func(t *testing.T) {
+		_, ok := validation.Describe(validation.Group("update", constraints.Required))
+		assert.False(t, ok)
+	})
+}
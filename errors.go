@@ -0,0 +1,126 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/seeruk/go-validation/validationpb"
+	"google.golang.org/grpc/status"
+)
+
+// maxErrorViolations is the maximum number of violations described in a ViolationsError's message.
+const maxErrorViolations = 3
+
+// ViolationsError is an error that holds the violations produced by validation, allowing them to
+// be returned like any other error, and retrieved using errors.As, even if wrapped. It also
+// implements the interface used by gRPC's status package, so that it's converted into a status
+// with ViolationsToStatus when returned from a gRPC handler.
+type ViolationsError struct {
+	Violations []ConstraintViolation
+}
+
+// ViolationsToError returns a new ViolationsError holding the given violations, or nil if there
+// are no error-level violations (see Severity). Warnings are kept alongside any errors.
+func ViolationsToError(violations []ConstraintViolation) error {
+	if !HasErrors(violations) {
+		return nil
+	}
+
+	return &ViolationsError{Violations: violations}
+}
+
+// ViolationsFromError returns the violations held by the given error, if it is, or wraps, a
+// ViolationsError, or a gRPC status error containing violations. Otherwise, nil is returned.
+func ViolationsFromError(err error) []ConstraintViolation {
+	if err == nil {
+		return nil
+	}
+
+	var violationsErr *ViolationsError
+	if errors.As(err, &violationsErr) {
+		return violationsErr.Violations
+	}
+
+	if sts, ok := status.FromError(err); ok {
+		if violations := ViolationsFromStatus(sts); len(violations) > 0 {
+			return violations
+		}
+	}
+
+	return nil
+}
+
+// ViolationsErrorFromStatus returns a ViolationsError holding the violations in the given gRPC
+// status, or nil if it doesn't contain any violations.
+func ViolationsErrorFromStatus(sts *status.Status) *ViolationsError {
+	violations := ViolationsFromStatus(sts)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &ViolationsError{Violations: violations}
+}
+
+// Error returns a summary of the violations held by this error, e.g.
+// "validation failed: .name: a value is required; .age: minimum value not met".
+func (e *ViolationsError) Error() string {
+	return summarizeViolations(e.Violations, maxErrorViolations)
+}
+
+// GRPCStatus returns the gRPC status representation of this error, see ViolationsToStatus. As this
+// is an error, the status always fails, even if it holds no error-level violations.
+func (e *ViolationsError) GRPCStatus() *status.Status {
+	return violationsStatus(e.Violations)
+}
+
+// ToProto returns the ProtoBuf representation of the violations held by this error.
+func (e *ViolationsError) ToProto() *validationpb.ConstraintViolations {
+	return ConstraintViolationsToProto(e.Violations)
+}
+
+// summarizeViolations returns a summary of the first n of the given violations, see
+// ViolationsError.Error.
+func summarizeViolations(violations []ConstraintViolation, n int) string {
+	if len(violations) == 0 {
+		return "validation failed"
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("validation failed: ")
+
+	for i, violation := range violations {
+		if i == n {
+			sb.WriteString(fmt.Sprintf(" (and %d more)", len(violations)-i))
+			break
+		}
+
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+
+		sb.WriteString(violation.Path)
+		sb.WriteString(": ")
+		sb.WriteString(violation.Message)
+	}
+
+	return sb.String()
+}
+
+// ValidateErr is exactly like Validate, except any violations are returned as a ViolationsError,
+// and nil is returned if the value is valid (i.e. there are no error-level violations).
+func ValidateErr(value any, constraints ...Constraint) error {
+	return ValidateContextErr(NewContext(value), constraints...)
+}
+
+// ValidateContextErr is exactly like ValidateContext, except any violations are returned as a
+// ViolationsError, and nil is returned if the value is valid. If the given Context has a cancelled
+// context.Context attached, the context's error is returned instead.
+func ValidateContextErr(ctx Context, constraints ...Constraint) error {
+	violations, err := validate(ctx, constraints...)
+	if err != nil {
+		return err
+	}
+
+	return ViolationsToError(violations)
+}
@@ -0,0 +1,137 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testViolations() []validation.ConstraintViolation {
+	return []validation.ConstraintViolation{
+		{Path: ".name", PathKind: validation.PathKindValue, Message: "a value is required"},
+		{Path: ".age", PathKind: validation.PathKindValue, Message: "minimum value not met", Details: map[string]any{
+			"minimum": float64(18),
+		}},
+	}
+}
+
+func TestViolationsToError(t *testing.T) {
+	t.Run("should return nil if there are no violations", func(t *testing.T) {
+		assert.NoError(t, validation.ViolationsToError(nil))
+	})
+
+	t.Run("should return a ViolationsError holding the given violations", func(t *testing.T) {
+		err := validation.ViolationsToError(testViolations())
+
+		var violationsErr *validation.ViolationsError
+		require.True(t, errors.As(err, &violationsErr))
+		assert.Equal(t, testViolations(), violationsErr.Violations)
+	})
+}
+
+func TestViolationsFromError(t *testing.T) {
+	t.Run("should return nil for a nil error", func(t *testing.T) {
+		assert.Nil(t, validation.ViolationsFromError(nil))
+	})
+
+	t.Run("should return nil for errors that don't hold violations", func(t *testing.T) {
+		assert.Nil(t, validation.ViolationsFromError(errors.New("oops")))
+		assert.Nil(t, validation.ViolationsFromError(status.Error(codes.NotFound, "not found")))
+	})
+
+	t.Run("should return violations from wrapped ViolationsErrors", func(t *testing.T) {
+		err := fmt.Errorf("failed to create user: %w", validation.ViolationsToError(testViolations()))
+		assert.Equal(t, testViolations(), validation.ViolationsFromError(err))
+	})
+
+	t.Run("should return violations from gRPC status errors", func(t *testing.T) {
+		err := validation.ViolationsToStatus(testViolations()).Err()
+		assert.Equal(t, testViolations(), validation.ViolationsFromError(err))
+	})
+}
+
+func TestViolationsErrorFromStatus(t *testing.T) {
+	t.Run("should return nil if the status holds no violations", func(t *testing.T) {
+		assert.Nil(t, validation.ViolationsErrorFromStatus(status.New(codes.Internal, "oops")))
+	})
+
+	t.Run("should return a ViolationsError holding the violations in the status", func(t *testing.T) {
+		err := validation.ViolationsErrorFromStatus(validation.ViolationsToStatus(testViolations()))
+		require.NotNil(t, err)
+		assert.Equal(t, testViolations(), err.Violations)
+	})
+}
+
+func TestViolationsError_Error(t *testing.T) {
+	t.Run("should summarise the violations", func(t *testing.T) {
+		err := &validation.ViolationsError{Violations: testViolations()}
+		assert.Equal(t, "validation failed: .name: a value is required; .age: minimum value not met", err.Error())
+	})
+
+	t.Run("should only describe the first few violations", func(t *testing.T) {
+		violations := append(testViolations(), testViolations()...)
+
+		err := &validation.ViolationsError{Violations: violations}
+		assert.Equal(t, "validation failed: .name: a value is required; .age: minimum value not met; .name: a value is required (and 1 more)", err.Error())
+	})
+
+	t.Run("should return a generic message if there are no violations", func(t *testing.T) {
+		err := &validation.ViolationsError{}
+		assert.Equal(t, "validation failed", err.Error())
+	})
+}
+
+func TestViolationsError_GRPCStatus(t *testing.T) {
+	t.Run("should be converted to a status by the gRPC status package, even when wrapped", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", validation.ViolationsToError(testViolations()))
+
+		sts, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, sts.Code())
+		assert.Equal(t, testViolations(), validation.ViolationsFromStatus(sts))
+	})
+
+	t.Run("should not be converted to an OK status if it holds no error-level violations", func(t *testing.T) {
+		err := &validation.ViolationsError{}
+		assert.Equal(t, codes.InvalidArgument, err.GRPCStatus().Code())
+	})
+}
+
+func TestViolationsError_ToProto(t *testing.T) {
+	t.Run("should round-trip through the proto representation", func(t *testing.T) {
+		err := &validation.ViolationsError{Violations: testViolations()}
+		assert.Equal(t, testViolations(), validation.ConstraintViolationsFromProto(err.ToProto()))
+	})
+}
+
+func TestValidateErr(t *testing.T) {
+	t.Run("should return nil if the value is valid", func(t *testing.T) {
+		assert.NoError(t, validation.ValidateErr(1, constraints.Required))
+	})
+
+	t.Run("should return a ViolationsError if the value is invalid", func(t *testing.T) {
+		err := validation.ValidateErr(0, constraints.Required)
+
+		var violationsErr *validation.ViolationsError
+		require.True(t, errors.As(err, &violationsErr))
+		assert.Len(t, violationsErr.Violations, 1)
+	})
+}
+
+func TestValidateContextErr(t *testing.T) {
+	t.Run("should return the context's error if cancelled", func(t *testing.T) {
+		stdCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := validation.ValidateContextErr(validation.NewContext(0).WithContext(stdCtx), constraints.Required)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// funcs holds information about ConstraintFuncs that can't be found without calling them, keyed by
+// the address of their closures, see registerFunc.
+var funcs sync.Map // map[uintptr]*funcInfo
+
+// funcInfo holds information about a ConstraintFunc, see funcs.
+type funcInfo struct {
+	// code is the address of the code of the ConstraintFunc. The address of a closure may be reused
+	// once it's garbage collected, before its information is removed, so this is checked to make
+	// sure information isn't found for a different function.
+	code uintptr
+	// descriptions and describedAs describe the ConstraintFunc, see DescribeFunc and DescribeFuncAs.
+	descriptions []Description
+	describedAs  []Constraint
+	// wrapped holds the constraints the ConstraintFunc may apply, and group is true if it's a Group,
+	// see appliesGroups.
+	wrapped []Constraint
+	group   bool
+}
+
+// registerFunc returns a ConstraintFunc that calls the given function, with the given information.
+func registerFunc(fn ConstraintFunc, info *funcInfo) ConstraintFunc {
+	// The given function may be shared by other ConstraintFuncs, so a new closure is made to have
+	// an address of its own. As every closure made here is registered, the address of its code also
+	// tells registered ConstraintFuncs apart from any others.
+	registered := ConstraintFunc(func(ctx Context) []ConstraintViolation {
+		return fn(ctx)
+	})
+
+	closure := funcClosure(registered)
+	info.code = *(*uintptr)(closure)
+	funcs.Store(uintptr(closure), info)
+
+	// Constraints can be made on the fly, so their information must go when they do. If the address
+	// is reused by another registered ConstraintFunc first, its information is kept.
+	runtime.AddCleanup((*byte)(closure), func(key uintptr) {
+		funcs.CompareAndDelete(key, info)
+	}, uintptr(closure))
+
+	return registered
+}
+
+// lookupFunc returns the information registered for the given ConstraintFunc, and true, or false if
+// it wasn't returned by registerFunc.
+func lookupFunc(fn ConstraintFunc) (*funcInfo, bool) {
+	if fn == nil {
+		return nil, false
+	}
+
+	closure := funcClosure(fn)
+
+	value, ok := funcs.Load(uintptr(closure))
+	if !ok {
+		return nil, false
+	}
+
+	info := value.(*funcInfo)
+	if info.code != *(*uintptr)(closure) {
+		return nil, false
+	}
+
+	return info, true
+}
+
+// funcClosure returns the address of the closure of the given ConstraintFunc, which starts with the
+// address of its code.
+func funcClosure(fn ConstraintFunc) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&fn))
+}
@@ -0,0 +1,174 @@
+package validation
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// DefaultGroup is the name of the group that constraints belong to if they're not in any other
+// group (see Group). Unless other groups are selected on a Context, only constraints in the default
+// group are applied.
+const DefaultGroup = "default"
+
+// Group returns a Constraint that only applies the given constraints if the group with the given
+// name is active on the Context (see Context.Groups). This allows the same constraints to be used
+// to validate a value in different scenarios, e.g.:
+//
+//	validation.Fields{
+//		"ID":   validation.Group("update", constraints.Required),
+//		"Name": constraints.Required,
+//	}
+//
+// Violations produced by the given constraints have their Group set to the given name. Groups can
+// be nested, in which case all of the groups must be active for the constraints to be applied.
+//
+// Constraints outside any group are in the default group, so if it isn't active, they're skipped.
+// Constraints that apply others, like Fields, When, or Sequence, are still applied so that any
+// groups within them can be. Custom constraints that apply others should use WrapFunc for this.
+func Group(name string, constraints ...Constraint) ConstraintFunc {
+	group := name
+	if name == DefaultGroup {
+		group = ""
+	}
+
+	grouped := make(Constraints, 0, len(constraints))
+	for _, c := range constraints {
+		grouped = append(grouped, groupConstraint{group: group, constraint: c})
+	}
+
+	return registerFunc(func(ctx Context) []ConstraintViolation {
+		if !ctx.groupActive(name) {
+			return nil
+		}
+
+		ctx.group = group
+
+		return grouped.violations(ctx)
+	}, &funcInfo{group: true})
+}
+
+// groupConstraint sets the group of any violations produced by a constraint in a group that don't
+// have one yet (i.e. those not made using the Context).
+type groupConstraint struct {
+	group      string
+	constraint Constraint
+}
+
+// Violations ...
+func (gc groupConstraint) Violations(ctx Context) []ConstraintViolation {
+	violations := gc.constraint.Violations(ctx)
+	for i := range violations {
+		if violations[i].Group == "" {
+			violations[i].Group = gc.group
+		}
+	}
+
+	return violations
+}
+
+// WithGroups returns an Option that sets the active groups, see Context.Groups.
+func WithGroups(groups ...string) Option {
+	return func(ctx *Context) {
+		ctx.Groups = groups
+	}
+}
+
+// WithGroupSequence returns an Option that sets the group sequence, see Context.GroupSequence.
+func WithGroupSequence(groups ...string) Option {
+	return func(ctx *Context) {
+		ctx.GroupSequence = groups
+	}
+}
+
+// groupActive returns true if the group with the given name is active on this Context.
+func (c *Context) groupActive(name string) bool {
+	if len(c.Groups) == 0 {
+		return name == DefaultGroup
+	}
+
+	return slices.Contains(c.Groups, name)
+}
+
+// WrapFunc returns a ConstraintFunc that calls the given function, which applies the given
+// constraints. Unlike other constraints outside any group, it isn't skipped if the default group
+// isn't active, if any of the given constraints could be in an active group, see Group.
+func WrapFunc(fn ConstraintFunc, constraints ...Constraint) ConstraintFunc {
+	return registerFunc(fn, &funcInfo{wrapped: constraints})
+}
+
+// apply returns the violations of the given constraint, unless it's in the default group and the
+// default group isn't active on this Context, in which case it's skipped, see Group.
+func (c *Context) apply(constraint Constraint) []ConstraintViolation {
+	if c.group == "" && !c.groupActive(DefaultGroup) && !appliesGroups(constraint) {
+		return nil
+	}
+
+	return constraint.Violations(*c)
+}
+
+// appliesGroups returns true if the given constraint is a Group, or applies constraints that may be
+// in a group. Constraints that apply others to the values beneath the current value (e.g. Lazy, or
+// the constraints of Validatable values) can't be checked without applying them, so they might.
+func appliesGroups(constraint Constraint) bool {
+	switch c := constraint.(type) {
+	case Constraints:
+		return anyAppliesGroups(slices.Values(c))
+	case Elements:
+		return anyAppliesGroups(slices.Values(c))
+	case Keys:
+		return anyAppliesGroups(slices.Values(c))
+	case Fields:
+		return anyAppliesGroups(maps.Values(c))
+	case ProtoFields:
+		return anyAppliesGroups(maps.Values(c))
+	case Map:
+		return anyAppliesGroups(maps.Values(c))
+	case OrderedFields:
+		return slices.ContainsFunc(c, func(field OrderedField) bool {
+			return appliesGroups(field.Constraint)
+		})
+	case ConstraintFunc:
+		info, ok := lookupFunc(c)
+		return ok && (info.group || anyAppliesGroups(slices.Values(info.wrapped)))
+	case Lazy, *lazyDynamic, *structConstraint, *compiledFields, deepConstraint:
+		return true
+	}
+
+	return false
+}
+
+// anyAppliesGroups returns true if any of the given constraints applies groups, see appliesGroups.
+func anyAppliesGroups(constraints iter.Seq[Constraint]) bool {
+	for constraint := range constraints {
+		if appliesGroups(constraint) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateGroupSequence validates each group in the group sequence on the given Context in turn,
+// stopping after the first group that produces any error-level violations.
+func validateGroupSequence(ctx Context, constraints ...Constraint) ([]ConstraintViolation, error) {
+	sequence := ctx.GroupSequence
+	ctx.GroupSequence = nil
+
+	var violations []ConstraintViolation
+	for _, group := range sequence {
+		ctx.Groups = []string{group}
+
+		groupViolations, err := validate(ctx, constraints...)
+		if err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, groupViolations...)
+		if HasErrors(groupViolations) {
+			break
+		}
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,162 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type groupTester struct {
+	ID    string `validation:"id"`
+	Name  string `validation:"name"`
+	Email string `validation:"email"`
+	Role  string `validation:"role"`
+}
+
+func groupConstraints() validation.Constraint {
+	return validation.Fields{
+		"ID":    validation.Group("update", constraints.Required),
+		"Name":  constraints.Required,
+		"Email": validation.Group("expensive", constraints.MinLength(100)),
+		"Role": validation.Group("admin",
+			constraints.Required,
+			validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+				return []validation.ConstraintViolation{{Path: ".role", Message: "manual violation"}}
+			}),
+		),
+	}
+}
+
+func TestGroup(t *testing.T) {
+	value := groupTester{Email: "test@example.com"}
+
+	t.Run("should only apply the default group if no groups are selected", func(t *testing.T) {
+		violations := validation.Validate(value, groupConstraints())
+		assert.Equal(t, []string{".name"}, deepPaths(violations))
+		assert.Equal(t, "", violations[0].Group)
+	})
+
+	t.Run("should only apply constraints in the selected groups", func(t *testing.T) {
+		ctx := validation.NewContext(value, validation.WithGroups("update"))
+
+		violations := validation.ValidateContext(ctx, groupConstraints())
+		require.Equal(t, []string{".id"}, deepPaths(violations))
+		assert.Equal(t, "update", violations[0].Group)
+	})
+
+	t.Run("should apply the default group if it's selected along with other groups", func(t *testing.T) {
+		ctx := validation.NewContext(value, validation.WithGroups(validation.DefaultGroup, "update"))
+
+		violations := validation.ValidateContext(ctx, groupConstraints())
+		assert.Equal(t, []string{".id", ".name"}, deepPaths(violations))
+	})
+
+	t.Run("should set the group of violations not made using the Context", func(t *testing.T) {
+		ctx := validation.NewContext(value, validation.WithGroups("admin"))
+
+		violations := validation.ValidateContext(ctx, groupConstraints())
+		require.Len(t, violations, 2)
+		assert.Equal(t, "admin", violations[0].Group)
+		assert.Equal(t, "admin", violations[1].Group)
+	})
+
+	t.Run("should require all nested groups to be active", func(t *testing.T) {
+		constraint := validation.Group("admin", validation.Group("update", constraints.Required))
+
+		ctx := validation.NewContext("", validation.WithGroups("admin"))
+		assert.Empty(t, validation.ValidateContext(ctx, constraint))
+
+		ctx = validation.NewContext("", validation.WithGroups("admin", "update"))
+		violations := validation.ValidateContext(ctx, constraint)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "update", violations[0].Group)
+	})
+
+	t.Run("should keep the group of violations customised by Details", func(t *testing.T) {
+		ctx := validation.NewContext("", validation.WithGroups("update"))
+
+		violations := validation.ValidateContext(ctx, constraints.Details(validation.Group("update", constraints.Required), "custom"))
+		require.Len(t, violations, 1)
+		assert.Equal(t, "update", violations[0].Group)
+	})
+
+	t.Run("should not stop on violations from inactive groups when failing fast", func(t *testing.T) {
+		ctx := validation.NewContext(value, validation.WithGroups("update"))
+		ctx.FailFast = true
+
+		violations := validation.ValidateContext(ctx, validation.OrderedFields{
+			validation.Field("Name", constraints.Required),
+			validation.Field("ID", validation.Group("update", constraints.Required)),
+		})
+
+		assert.Equal(t, []string{".id"}, deepPaths(violations))
+	})
+}
+
+func TestGroup_DefaultGroup(t *testing.T) {
+	t.Run("should not apply constraints in the default group if it isn't active", func(t *testing.T) {
+		var applied bool
+		custom := validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			applied = true
+			return nil
+		})
+
+		ctx := validation.NewContext(groupTester{}, validation.WithGroups("update"))
+
+		violations := validation.ValidateContext(ctx, validation.Fields{
+			"ID":   validation.Group("update", constraints.Required),
+			"Name": validation.Sequence(custom, constraints.Required),
+			"Role": validation.When(true, custom),
+		})
+
+		assert.Equal(t, []string{".id"}, deepPaths(violations))
+		assert.False(t, applied)
+	})
+
+	t.Run("should apply groups within custom constraints that use WrapFunc", func(t *testing.T) {
+		grouped := validation.Group("update", constraints.Required)
+
+		// Violations from constraints in active groups are kept, whatever their group is.
+		custom := validation.WrapFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			violations := grouped.Violations(ctx)
+			for i := range violations {
+				violations[i].Group = ""
+			}
+
+			return violations
+		}, grouped)
+
+		ctx := validation.NewContext("", validation.WithGroups("update"))
+
+		violations := validation.ValidateContext(ctx, custom)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "", violations[0].Group)
+
+		// Without WrapFunc, the custom constraint is in the default group.
+		violations = validation.ValidateContext(ctx, validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			return grouped.Violations(ctx)
+		}))
+
+		assert.Empty(t, violations)
+	})
+}
+
+func TestWithGroupSequence(t *testing.T) {
+	t.Run("should stop after the first group with errors", func(t *testing.T) {
+		ctx := validation.NewContext(groupTester{Email: "test@example.com"}, validation.WithGroupSequence(validation.DefaultGroup, "expensive"))
+
+		violations := validation.ValidateContext(ctx, groupConstraints())
+		assert.Equal(t, []string{".name"}, deepPaths(violations))
+	})
+
+	t.Run("should validate each group in turn while they pass", func(t *testing.T) {
+		ctx := validation.NewContext(groupTester{Name: "Test", Email: "test@example.com"}, validation.WithGroupSequence(validation.DefaultGroup, "expensive", "update"))
+
+		violations := validation.ValidateContext(ctx, groupConstraints())
+		require.Equal(t, []string{".email"}, deepPaths(violations))
+		assert.Equal(t, "expensive", violations[0].Group)
+	})
+}
@@ -0,0 +1,116 @@
+package grpcvalidation
+
+import (
+	"context"
+
+	"github.com/seeruk/go-validation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pathPrefixKey is the context key used to hold the path prefix set by ContextWithPathPrefix.
+type pathPrefixKey struct{}
+
+// WithPathPrefix returns an Option that adds the given segments to the start of the paths of any
+// violations returned to a client, e.g. when a gateway forwards a value nested in its own request to
+// another service. See validation.PrefixViolations.
+func WithPathPrefix(prefix ...validation.PathSegment) Option {
+	return func(o *options) {
+		o.pathPrefix = prefix
+	}
+}
+
+// ContextWithPathPrefix returns a copy of the given context.Context, that adds the given segments to
+// the start of the paths of any violations returned by calls made with it, after any prefix set
+// using WithPathPrefix. This allows the prefix to be set for each call.
+func ContextWithPathPrefix(ctx context.Context, prefix ...validation.PathSegment) context.Context {
+	return context.WithValue(ctx, pathPrefixKey{}, prefix)
+}
+
+// UnaryClientInterceptor returns a gRPC unary client interceptor that turns InvalidArgument statuses
+// that hold violations into a *validation.ViolationsError, so that callers can get the violations
+// using errors.As, or validation.ViolationsFromError. As a ViolationsError is converted back into an
+// InvalidArgument status by gRPC, it can be returned from a handler as-is. Statuses with any other
+// code are returned as they are, so that their code isn't lost, though validation.ViolationsFromError
+// can still read any violations they hold.
+//
+// The WithPathPrefix, WithSkip, and WithValidationOptions options apply to client interceptors, the
+// latter being used to get the PathFormatter used to render prefixed paths.
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		return o.clientError(ctx, method, invoker(ctx, method, req, reply, cc, callOpts...))
+	}
+}
+
+// StreamClientInterceptor returns a gRPC stream client interceptor that turns InvalidArgument
+// statuses that hold violations into a *validation.ViolationsError, exactly like
+// UnaryClientInterceptor, whether they're returned when the stream is created, or by RecvMsg.
+func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			return nil, o.clientError(ctx, method, err)
+		}
+
+		return &clientStream{
+			ClientStream: cs,
+			ctx:          ctx,
+			method:       method,
+			options:      o,
+		}, nil
+	}
+}
+
+// clientStream is a grpc.ClientStream that turns InvalidArgument statuses that hold violations into
+// errors.
+type clientStream struct {
+	grpc.ClientStream
+
+	ctx     context.Context
+	method  string
+	options *options
+}
+
+// RecvMsg ...
+func (s *clientStream) RecvMsg(m any) error {
+	return s.options.clientError(s.ctx, s.method, s.ClientStream.RecvMsg(m))
+}
+
+// clientError returns a *validation.ViolationsError in place of the given error, returned by a call
+// to the method with the given full name, if it's an InvalidArgument status that holds violations
+// (in either of the forms read by validation.ViolationsFromStatus). Otherwise, the given error is
+// returned as-is.
+func (o *options) clientError(ctx context.Context, fullMethod string, err error) error {
+	if err == nil || (o.skip != nil && o.skip(ctx, fullMethod)) {
+		return err
+	}
+
+	sts, ok := status.FromError(err)
+	if !ok || sts.Code() != codes.InvalidArgument {
+		// A ViolationsError is always converted into an InvalidArgument status, so converting any
+		// other status would change its code, and drop its message and details.
+		return err
+	}
+
+	violations := validation.ViolationsFromStatus(sts)
+	if len(violations) == 0 {
+		return err
+	}
+
+	prefix := o.pathPrefix
+	if ctxPrefix, ok := ctx.Value(pathPrefixKey{}).([]validation.PathSegment); ok {
+		prefix = append(prefix[:len(prefix):len(prefix)], ctxPrefix...)
+	}
+
+	if len(prefix) > 0 {
+		formatter := validation.NewContext(nil, o.validationOptions...).PathFormatter
+		violations = validation.PrefixViolations(violations, formatter, prefix...)
+	}
+
+	return &validation.ViolationsError{Violations: violations}
+}
@@ -0,0 +1,162 @@
+package grpcvalidation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testStatusErr() error {
+	return validation.ViolationsToStatus([]validation.ConstraintViolation{
+		{
+			Path:     ".name",
+			Segments: []validation.PathSegment{validation.FieldSegment("Name", "name")},
+			Message:  "a value is required",
+		},
+	}).Err()
+}
+
+func invokerReturning(err error) grpc.UnaryInvoker {
+	return func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return err
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	t.Run("should return a violations error for statuses with violations", func(t *testing.T) {
+		interceptor := UnaryClientInterceptor()
+		err := interceptor(context.Background(), testMethod, nil, nil, nil, invokerReturning(testStatusErr()))
+
+		var violationsErr *validation.ViolationsError
+		require.True(t, errors.As(err, &violationsErr))
+		require.Len(t, violationsErr.Violations, 1)
+		assert.Equal(t, ".name", violationsErr.Violations[0].Path)
+
+		// The error can be returned from a handler as-is.
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("should return other errors as-is", func(t *testing.T) {
+		interceptor := UnaryClientInterceptor()
+
+		assert.NoError(t, interceptor(context.Background(), testMethod, nil, nil, nil, invokerReturning(nil)))
+
+		notFound := status.Error(codes.NotFound, "not found")
+		assert.Equal(t, notFound, interceptor(context.Background(), testMethod, nil, nil, nil, invokerReturning(notFound)))
+
+		invalid := status.Error(codes.InvalidArgument, "invalid")
+		assert.Equal(t, invalid, interceptor(context.Background(), testMethod, nil, nil, nil, invokerReturning(invalid)))
+	})
+
+	t.Run("should not convert errors of skipped methods", func(t *testing.T) {
+		interceptor := UnaryClientInterceptor(WithSkip(func(context.Context, string) bool { return true }))
+		err := interceptor(context.Background(), testMethod, nil, nil, nil, invokerReturning(testStatusErr()))
+
+		var violationsErr *validation.ViolationsError
+		assert.False(t, errors.As(err, &violationsErr))
+	})
+
+	t.Run("should add path prefixes to violations", func(t *testing.T) {
+		interceptor := UnaryClientInterceptor(WithPathPrefix(validation.FieldSegment("Orders", "orders")))
+		ctx := ContextWithPathPrefix(context.Background(), validation.IndexSegment(2))
+
+		err := interceptor(ctx, testMethod, nil, nil, nil, invokerReturning(testStatusErr()))
+
+		violations := validation.ViolationsFromError(err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".orders.[2].name", violations[0].Path)
+	})
+
+	t.Run("should render prefixed paths using the path formatter", func(t *testing.T) {
+		interceptor := UnaryClientInterceptor(
+			WithPathPrefix(validation.FieldSegment("Order", "order")),
+			WithValidationOptions(validation.WithPathFormatter(validation.FormatJSONPointer)),
+		)
+
+		err := interceptor(context.Background(), testMethod, nil, nil, nil, invokerReturning(testStatusErr()))
+
+		violations := validation.ViolationsFromError(err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "/order/name", violations[0].Path)
+	})
+}
+
+type testClientStream struct {
+	grpc.ClientStream
+
+	err error
+}
+
+func (s *testClientStream) RecvMsg(any) error {
+	return s.err
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	t.Run("should return a violations error when creating the stream fails", func(t *testing.T) {
+		streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return nil, testStatusErr()
+		}
+
+		_, err := StreamClientInterceptor()(context.Background(), &grpc.StreamDesc{}, nil, testMethod, streamer)
+
+		var violationsErr *validation.ViolationsError
+		assert.True(t, errors.As(err, &violationsErr))
+	})
+
+	t.Run("should return a violations error from RecvMsg", func(t *testing.T) {
+		streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return &testClientStream{err: testStatusErr()}, nil
+		}
+
+		cs, err := StreamClientInterceptor()(context.Background(), &grpc.StreamDesc{}, nil, testMethod, streamer)
+		require.NoError(t, err)
+
+		var violationsErr *validation.ViolationsError
+		assert.True(t, errors.As(cs.RecvMsg(nil), &violationsErr))
+	})
+}
+
+func TestUnaryClientInterceptor_StatusOptions(t *testing.T) {
+	t.Run("should return a violations error for statuses with bad request details", func(t *testing.T) {
+		sts := validation.ViolationsToStatus(
+			[]validation.ConstraintViolation{{Path: ".name", Message: "a value is required"}},
+			validation.WithBadRequest(),
+			validation.WithoutConstraintViolations(),
+		)
+
+		err := UnaryClientInterceptor()(context.Background(), testMethod, nil, nil, nil, invokerReturning(sts.Err()))
+
+		var violationsErr *validation.ViolationsError
+		require.True(t, errors.As(err, &violationsErr))
+		require.Len(t, violationsErr.Violations, 1)
+		assert.Equal(t, ".name", violationsErr.Violations[0].Path)
+	})
+
+	t.Run("should return statuses with other codes as-is", func(t *testing.T) {
+		sts := validation.ViolationsToStatus(
+			[]validation.ConstraintViolation{{Path: ".name", Message: "a value is required"}},
+			validation.WithStatusCode(codes.FailedPrecondition),
+			validation.WithStatusSummary(1),
+			validation.WithBadRequest(),
+		)
+
+		err := UnaryClientInterceptor()(context.Background(), testMethod, nil, nil, nil, invokerReturning(sts.Err()))
+
+		var violationsErr *validation.ViolationsError
+		assert.False(t, errors.As(err, &violationsErr))
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, sts.Message(), status.Convert(err).Message())
+		assert.Len(t, status.Convert(err).Details(), 2)
+
+		violations := validation.ViolationsFromError(err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".name", violations[0].Path)
+	})
+}
@@ -0,0 +1,136 @@
+// Package grpcvalidation provides gRPC interceptors that validate messages automatically, returning
+// any violations as a gRPC status, instead of every handler having to do it itself, and that turn
+// such statuses back into errors holding the violations on the client side.
+package grpcvalidation
+
+import (
+	"context"
+
+	"github.com/seeruk/go-validation"
+	"google.golang.org/grpc/status"
+)
+
+// Registry holds the constraints used to validate the request messages of gRPC methods, keyed by
+// the full name of each method, e.g. "/package.Service/Method". Constraints in a Registry take
+// precedence over those of request messages that implement validation.Validatable.
+type Registry map[string]validation.Constraint
+
+// SkipFunc returns true if the requests of the method with the given full name shouldn't be
+// validated.
+type SkipFunc func(ctx context.Context, fullMethod string) bool
+
+// StatusFunc returns the gRPC status to return for a request to the method with the given full
+// name, that has the given violations.
+type StatusFunc func(ctx context.Context, fullMethod string, violations []validation.ConstraintViolation) *status.Status
+
+// Option is a function that configures an interceptor.
+type Option func(*options)
+
+// options holds the configuration of an interceptor.
+type options struct {
+	registry          Registry
+	skip              SkipFunc
+	status            StatusFunc
+	validationOptions []validation.Option
+	statusOptions     []validation.StatusOption
+	pathPrefix        []validation.PathSegment
+}
+
+// newOptions returns the configuration made using the given option(s).
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.status == nil {
+		o.status = func(_ context.Context, _ string, violations []validation.ConstraintViolation) *status.Status {
+			return validation.ViolationsToStatus(violations, o.statusOptions...)
+		}
+	}
+
+	return o
+}
+
+// WithRegistry returns an Option that sets the Registry used to look up the constraints of each
+// method.
+func WithRegistry(registry Registry) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
+
+// WithSkip returns an Option that skips validation of the methods the given SkipFunc returns true
+// for.
+func WithSkip(skip SkipFunc) Option {
+	return func(o *options) {
+		o.skip = skip
+	}
+}
+
+// WithStatusFunc returns an Option that customises the gRPC status returned when a message has
+// violations. By default, validation.ViolationsToStatus is used, see WithStatusOptions.
+func WithStatusFunc(fn StatusFunc) Option {
+	return func(o *options) {
+		o.status = fn
+	}
+}
+
+// WithStatusOptions returns an Option that sets the options given to validation.ViolationsToStatus
+// when a message has violations, e.g. to attach a google.rpc.BadRequest detail. Ignored if a
+// StatusFunc is set.
+func WithStatusOptions(opts ...validation.StatusOption) Option {
+	return func(o *options) {
+		o.statusOptions = opts
+	}
+}
+
+// WithValidationOptions returns an Option that sets the options used to create the
+// validation.Context of each message, e.g. to set the struct tag, or a Translator.
+func WithValidationOptions(opts ...validation.Option) Option {
+	return func(o *options) {
+		o.validationOptions = opts
+	}
+}
+
+// constraint returns the constraint used to validate the given message, sent to the method with the
+// given full name, or nil if it shouldn't be validated.
+func (o *options) constraint(ctx context.Context, fullMethod string, msg any) validation.Constraint {
+	if o.skip != nil && o.skip(ctx, fullMethod) {
+		return nil
+	}
+
+	if constraint, ok := o.registry[fullMethod]; ok {
+		return constraint
+	}
+
+	if validatable, ok := msg.(validation.Validatable); ok {
+		return validatable.Constraints()
+	}
+
+	return nil
+}
+
+// validate validates the given message, sent to the method with the given full name, returning a
+// gRPC status error if it's invalid.
+func (o *options) validate(ctx context.Context, fullMethod string, msg any) error {
+	constraint := o.constraint(ctx, fullMethod, msg)
+	if constraint == nil {
+		return nil
+	}
+
+	vctx := validation.NewContext(msg, o.validationOptions...).WithContext(ctx)
+
+	err := validation.ValidateContextErr(vctx, constraint)
+	if err == nil {
+		return nil
+	}
+
+	violations := validation.ViolationsFromError(err)
+	if len(violations) == 0 {
+		// The context was cancelled before validation completed.
+		return status.FromContextError(err).Err()
+	}
+
+	return o.status(ctx, fullMethod, violations).Err()
+}
@@ -0,0 +1,55 @@
+package grpcvalidation
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns a gRPC unary server interceptor that validates each request
+// before it's handled. If the request has any error-level violations, the handler isn't called,
+// and the status made by the StatusFunc is returned instead (an InvalidArgument status with
+// validationpb.ConstraintViolations details, by default).
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts...)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := o.validate(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC stream server interceptor that validates each message
+// received from the client. If a message has any error-level violations, the status made by the
+// StatusFunc is returned from RecvMsg, in place of the message.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts...)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{
+			ServerStream: ss,
+			fullMethod:   info.FullMethod,
+			options:      o,
+		})
+	}
+}
+
+// serverStream is a grpc.ServerStream that validates each message it receives.
+type serverStream struct {
+	grpc.ServerStream
+
+	fullMethod string
+	options    *options
+}
+
+// RecvMsg ...
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.options.validate(s.Context(), s.fullMethod, m)
+}
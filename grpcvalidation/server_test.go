@@ -0,0 +1,195 @@
+package grpcvalidation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/seeruk/go-validation/validationpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const testMethod = "/seeruk.validation.Test/Method"
+
+type validatableRequest struct {
+	Name string
+}
+
+func (r *validatableRequest) Constraints() validation.Constraint {
+	return validation.Fields{
+		"Name": constraints.Required,
+	}
+}
+
+func testRegistry() Registry {
+	return Registry{
+		testMethod: validation.ProtoFields{
+			"path": constraints.Required,
+		},
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+
+	var handled bool
+	handler := func(ctx context.Context, req any) (any, error) {
+		handled = true
+		return req, nil
+	}
+
+	t.Run("should call the handler if the request is valid", func(t *testing.T) {
+		handled = false
+
+		interceptor := UnaryServerInterceptor(WithRegistry(testRegistry()))
+		_, err := interceptor(context.Background(), &validationpb.ConstraintViolation{Path: ".test"}, info, handler)
+		assert.NoError(t, err)
+		assert.True(t, handled)
+	})
+
+	t.Run("should return an invalid argument status if the request is invalid", func(t *testing.T) {
+		handled = false
+
+		interceptor := UnaryServerInterceptor(WithRegistry(testRegistry()))
+		_, err := interceptor(context.Background(), &validationpb.ConstraintViolation{}, info, handler)
+		require.Error(t, err)
+		assert.False(t, handled)
+
+		sts := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, sts.Code())
+
+		violations := validation.ViolationsFromStatus(sts)
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".path", violations[0].Path)
+	})
+
+	t.Run("should use the constraints of validatable requests", func(t *testing.T) {
+		handled = false
+
+		interceptor := UnaryServerInterceptor()
+		_, err := interceptor(context.Background(), &validatableRequest{}, info, handler)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.False(t, handled)
+	})
+
+	t.Run("should call the handler if there are no constraints for the request", func(t *testing.T) {
+		handled = false
+
+		interceptor := UnaryServerInterceptor()
+		_, err := interceptor(context.Background(), &validationpb.ConstraintViolation{}, info, handler)
+		assert.NoError(t, err)
+		assert.True(t, handled)
+	})
+
+	t.Run("should not validate skipped methods", func(t *testing.T) {
+		handled = false
+
+		interceptor := UnaryServerInterceptor(WithRegistry(testRegistry()), WithSkip(func(_ context.Context, fullMethod string) bool {
+			return fullMethod == testMethod
+		}))
+
+		_, err := interceptor(context.Background(), &validationpb.ConstraintViolation{}, info, handler)
+		assert.NoError(t, err)
+		assert.True(t, handled)
+	})
+
+	t.Run("should use the status func to make the status", func(t *testing.T) {
+		interceptor := UnaryServerInterceptor(WithRegistry(testRegistry()), WithStatusFunc(func(_ context.Context, fullMethod string, violations []validation.ConstraintViolation) *status.Status {
+			return status.Newf(codes.FailedPrecondition, "%s: %d violation(s)", fullMethod, len(violations))
+		}))
+
+		_, err := interceptor(context.Background(), &validationpb.ConstraintViolation{}, info, handler)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, testMethod+": 1 violation(s)", status.Convert(err).Message())
+	})
+
+	t.Run("should use the status options to make the status", func(t *testing.T) {
+		interceptor := UnaryServerInterceptor(WithRegistry(testRegistry()), WithStatusOptions(validation.WithStatusCode(codes.FailedPrecondition)))
+
+		_, err := interceptor(context.Background(), &validationpb.ConstraintViolation{}, info, handler)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Len(t, validation.ViolationsFromError(err), 1)
+	})
+
+	t.Run("should use the validation options", func(t *testing.T) {
+		interceptor := UnaryServerInterceptor(
+			WithRegistry(testRegistry()),
+			WithValidationOptions(validation.WithPathFormatter(validation.FormatJSONPointer)),
+		)
+
+		_, err := interceptor(context.Background(), &validationpb.ConstraintViolation{}, info, handler)
+
+		violations := validation.ViolationsFromError(err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "/path", violations[0].Path)
+	})
+
+	t.Run("should return the context's error if it's cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		interceptor := UnaryServerInterceptor(WithRegistry(testRegistry()))
+		_, err := interceptor(ctx, &validationpb.ConstraintViolation{}, info, handler)
+		assert.Equal(t, codes.Canceled, status.Code(err))
+	})
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+
+	messages []proto.Message
+}
+
+func (s *testServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *testServerStream) RecvMsg(m any) error {
+	if len(s.messages) == 0 {
+		return io.EOF
+	}
+
+	proto.Merge(m.(proto.Message), s.messages[0])
+	s.messages = s.messages[1:]
+
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: testMethod}
+
+	t.Run("should validate each received message", func(t *testing.T) {
+		ss := &testServerStream{
+			messages: []proto.Message{
+				&validationpb.ConstraintViolation{Path: ".test"},
+				&validationpb.ConstraintViolation{},
+			},
+		}
+
+		var errs []error
+		handler := func(srv any, stream grpc.ServerStream) error {
+			for {
+				err := stream.RecvMsg(&validationpb.ConstraintViolation{})
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+
+				errs = append(errs, err)
+			}
+		}
+
+		interceptor := StreamServerInterceptor(WithRegistry(testRegistry()))
+		require.NoError(t, interceptor(nil, ss, info, handler))
+		require.Len(t, errs, 2)
+		assert.NoError(t, errs[0])
+		assert.Equal(t, codes.InvalidArgument, status.Code(errs[1]))
+	})
+}
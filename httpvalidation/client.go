@@ -0,0 +1,38 @@
+package httpvalidation
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/seeruk/go-validation"
+)
+
+// DecodeProblem decodes the body of the given response as a Problem, if it has the
+// ProblemContentType media type. Otherwise, nil is returned, and the body isn't read. The body is
+// not closed.
+func DecodeProblem(resp *http.Response) (*Problem, error) {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != ProblemContentType {
+		return nil, nil
+	}
+
+	var problem Problem
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		return nil, fmt.Errorf("httpvalidation: failed to decode problem: %w", err)
+	}
+
+	return &problem, nil
+}
+
+// ViolationsFromResponse returns the violations held by the Problem in the body of the given
+// response, if it has one (see DecodeProblem). The body is not closed.
+func ViolationsFromResponse(resp *http.Response) ([]validation.ConstraintViolation, error) {
+	problem, err := DecodeProblem(resp)
+	if err != nil || problem == nil {
+		return nil, err
+	}
+
+	return problem.Errors, nil
+}
@@ -0,0 +1,73 @@
+package httpvalidation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViolationsFromResponse(t *testing.T) {
+	t.Run("should return the violations from a problem response", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		require.NoError(t, WriteProblem(rec, testViolations()))
+
+		violations, err := ViolationsFromResponse(rec.Result())
+		require.NoError(t, err)
+		assert.Equal(t, testViolations(), violations)
+	})
+
+	t.Run("should accept media type parameters", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", ProblemContentType+"; charset=utf-8")
+		_, _ = rec.WriteString(`{"status": 422, "errors": [{"path": ".name", "message": "a value is required"}]}`)
+
+		violations, err := ViolationsFromResponse(rec.Result())
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".name", violations[0].Path)
+	})
+
+	t.Run("should return nothing for other responses", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "application/json")
+		_, _ = rec.WriteString(`{"errors": [{"path": ".name"}]}`)
+
+		violations, err := ViolationsFromResponse(rec.Result())
+		assert.NoError(t, err)
+		assert.Nil(t, violations)
+	})
+
+	t.Run("should return an error if the problem can't be decoded", func(t *testing.T) {
+		resp := &http.Response{
+			Header: http.Header{"Content-Type": []string{ProblemContentType}},
+			Body:   http.NoBody,
+		}
+
+		_, err := ViolationsFromResponse(resp)
+		assert.Error(t, err)
+	})
+}
+
+func TestDecodeProblem(t *testing.T) {
+	t.Run("should decode all members of the problem", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", ProblemContentType)
+		_, _ = rec.WriteString(strings.TrimSpace(`
+			{"type": "https://example.com/invalid", "title": "Invalid", "status": 400, "detail": "bad", "instance": "/orders/1"}
+		`))
+
+		problem, err := DecodeProblem(rec.Result())
+		require.NoError(t, err)
+		assert.Equal(t, &Problem{
+			Type:     "https://example.com/invalid",
+			Title:    "Invalid",
+			Status:   400,
+			Detail:   "bad",
+			Instance: "/orders/1",
+		}, problem)
+	})
+}
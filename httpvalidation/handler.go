@@ -0,0 +1,141 @@
+package httpvalidation
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/seeruk/go-validation"
+)
+
+// HandlerFunc handles a request, given the value decoded from (and validated against) its body.
+type HandlerFunc[T any] func(w http.ResponseWriter, r *http.Request, value T)
+
+// Option is a function that configures a Handler.
+type Option func(*options)
+
+// options holds the configuration of a Handler.
+type options struct {
+	constraints        []validation.Constraint
+	validationOptions  []validation.Option
+	maxBodyBytes       int64
+	allowUnknownFields bool
+}
+
+// WithConstraints returns an Option that sets the constraints used to validate decoded values. If
+// none are set, the constraints of values that implement validation.Validatable are used instead.
+func WithConstraints(constraints ...validation.Constraint) Option {
+	return func(o *options) {
+		o.constraints = constraints
+	}
+}
+
+// WithValidationOptions returns an Option that sets the options used to create the
+// validation.Context of each decoded value, e.g. to set the struct tag to "json", so that paths use
+// the same names as the request body.
+func WithValidationOptions(opts ...validation.Option) Option {
+	return func(o *options) {
+		o.validationOptions = opts
+	}
+}
+
+// WithMaxBodyBytes returns an Option that limits the size of request bodies, see
+// http.MaxBytesReader. Larger bodies are rejected with a 413 (Content Too Large) problem.
+func WithMaxBodyBytes(n int64) Option {
+	return func(o *options) {
+		o.maxBodyBytes = n
+	}
+}
+
+// WithAllowUnknownFields returns an Option that stops members of the request body that don't match
+// any field of the value it's decoded into from being reported as violations.
+func WithAllowUnknownFields() Option {
+	return func(o *options) {
+		o.allowUnknownFields = true
+	}
+}
+
+// Handler returns an http.Handler that decodes the JSON body of each request into a new T, validates
+// it, then passes it to the given HandlerFunc. Problems with the body itself are reported alongside
+// any violations of the constraints, see validation.JSONBinder. If the body isn't valid JSON, a 400
+// (Bad Request) problem is written instead of calling the HandlerFunc, or if there are any other
+// error-level violations, a 422 (Unprocessable Content) problem is written (see WriteProblem).
+func Handler[T any](fn HandlerFunc[T], opts ...Option) http.Handler {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	binder := validation.JSONBinder{
+		Options:            o.validationOptions,
+		AllowUnknownFields: o.allowUnknownFields,
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := r.Body
+		if o.maxBodyBytes > 0 {
+			body = http.MaxBytesReader(w, body, o.maxBodyBytes)
+		}
+
+		var value T
+
+		constraints := o.constraints
+		if _, ok := asValidatable(&value); ok && len(constraints) == 0 {
+			// The constraints of Validatable values may depend on the value, so they're only fetched
+			// once the body has been decoded into it.
+			constraints = []validation.Constraint{validation.Lazy(func() validation.Constraint {
+				validatable, _ := asValidatable(&value)
+				return validatable.Constraints()
+			})}
+		}
+
+		violations, err := binder.Bind(r.Context(), body, &value, constraints...)
+		if err != nil {
+			if r.Context().Err() != nil {
+				// The request was cancelled, so there's no one to respond to.
+				return
+			}
+
+			status := http.StatusBadRequest
+
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				status = http.StatusRequestEntityTooLarge
+			}
+
+			problem := &Problem{
+				Title:  http.StatusText(status),
+				Status: status,
+				Detail: "request body could not be read: " + err.Error(),
+			}
+
+			_ = problem.Write(w)
+			return
+		}
+
+		if slices.ContainsFunc(violations, func(violation validation.ConstraintViolation) bool {
+			return violation.Code == validation.CodeInvalidJSON
+		}) {
+			_ = NewProblem(http.StatusBadRequest, violations).Write(w)
+			return
+		}
+
+		if validation.HasErrors(violations) {
+			_ = WriteProblem(w, violations)
+			return
+		}
+
+		fn(w, r, value)
+	})
+}
+
+// asValidatable returns the given value as a validation.Validatable, or the value it points to, if
+// either is one.
+func asValidatable[T any](value *T) (validation.Validatable, bool) {
+	if validatable, ok := any(value).(validation.Validatable); ok {
+		return validatable, true
+	}
+
+	validatable, ok := any(*value).(validation.Validatable)
+	return validatable, ok
+}
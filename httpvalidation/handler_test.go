@@ -0,0 +1,147 @@
+package httpvalidation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	Name string `json:"name"`
+}
+
+type validatableRequest struct {
+	Name string `json:"name"`
+}
+
+func (r *validatableRequest) Constraints() validation.Constraint {
+	return validation.Fields{
+		"Name": constraints.Required,
+	}
+}
+
+type conditionalRequest struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+func (r conditionalRequest) Constraints() validation.Constraint {
+	if r.Kind != "named" {
+		return validation.Constraints{}
+	}
+
+	return validation.Fields{
+		"Name": constraints.Required,
+	}
+}
+
+func serve(handler http.Handler, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	var handled *testRequest
+	fn := func(w http.ResponseWriter, r *http.Request, value testRequest) {
+		handled = &value
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	opts := []Option{
+		WithConstraints(validation.Fields{
+			"Name": constraints.Required,
+		}),
+		WithValidationOptions(func(ctx *validation.Context) {
+			ctx.StructTag = "json"
+		}),
+	}
+
+	t.Run("should call the handler with the decoded value if it's valid", func(t *testing.T) {
+		handled = nil
+
+		rec := serve(Handler(fn, opts...), `{"name": "test"}`)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		require.NotNil(t, handled)
+		assert.Equal(t, "test", handled.Name)
+	})
+
+	t.Run("should write a problem if the value is invalid", func(t *testing.T) {
+		handled = nil
+
+		rec := serve(Handler(fn, opts...), `{"name": ""}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Nil(t, handled)
+
+		violations, err := ViolationsFromResponse(rec.Result())
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".name", violations[0].Path)
+	})
+
+	t.Run("should write a problem if the body isn't valid JSON", func(t *testing.T) {
+		handled = nil
+
+		rec := serve(Handler(fn, opts...), `{"name": `)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+		assert.Nil(t, handled)
+
+		violations, err := ViolationsFromResponse(rec.Result())
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, validation.CodeInvalidJSON, violations[0].Code)
+	})
+
+	t.Run("should report values of the wrong type and unknown fields as violations", func(t *testing.T) {
+		handled = nil
+
+		rec := serve(Handler(fn, opts...), `{"name": 123, "age": 1}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Nil(t, handled)
+
+		violations, err := ViolationsFromResponse(rec.Result())
+		require.NoError(t, err)
+		require.Len(t, violations, 2)
+		assert.Equal(t, ".age", violations[0].Path)
+		assert.Equal(t, validation.CodeUnknownField, violations[0].Code)
+		assert.Equal(t, ".name", violations[1].Path)
+		assert.Equal(t, validation.CodeInvalidType, violations[1].Code)
+	})
+
+	t.Run("should allow unknown fields if configured to", func(t *testing.T) {
+		rec := serve(Handler(fn, append(opts, WithAllowUnknownFields())...), `{"name": "test", "age": 1}`)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should write a problem if the body is too large", func(t *testing.T) {
+		rec := serve(Handler(fn, append(opts, WithMaxBodyBytes(8))...), `{"name": "test"}`)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("should use the constraints of validatable values", func(t *testing.T) {
+		handler := Handler(func(w http.ResponseWriter, r *http.Request, value validatableRequest) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, serve(handler, `{}`).Code)
+		assert.Equal(t, http.StatusNoContent, serve(handler, `{"name": "test"}`).Code)
+	})
+
+	t.Run("should get the constraints of validatable values once they're decoded", func(t *testing.T) {
+		handler := Handler(func(w http.ResponseWriter, r *http.Request, value conditionalRequest) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		assert.Equal(t, http.StatusNoContent, serve(handler, `{}`).Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, serve(handler, `{"kind": "named"}`).Code)
+		assert.Equal(t, http.StatusNoContent, serve(handler, `{"kind": "named", "name": "test"}`).Code)
+	})
+}
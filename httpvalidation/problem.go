@@ -0,0 +1,69 @@
+// Package httpvalidation provides helpers for validating HTTP requests, and for returning any
+// violations as RFC 9457 problem details ("application/problem+json"), as well as for reading them
+// back on the client side.
+package httpvalidation
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/seeruk/go-validation"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details encoded as JSON.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details object, with the violations that caused the problem held
+// in the "errors" extension member.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type. If empty, it's "about:blank", in
+	// which case Title should be the HTTP status phrase.
+	Type string `json:"type,omitempty"`
+	// Title is a short, human-readable summary of the problem type.
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code of the response.
+	Status int `json:"status,omitempty"`
+	// Detail is a human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference that identifies this occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// Errors are the violations that caused the problem.
+	Errors []validation.ConstraintViolation `json:"errors,omitempty"`
+}
+
+// NewProblem returns a new Problem with the given HTTP status code, holding the given violations.
+// The detail of the Problem summarises the violations, see validation.ViolationsError.
+func NewProblem(status int, violations []validation.ConstraintViolation) *Problem {
+	return &Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: (&validation.ViolationsError{Violations: violations}).Error(),
+		Errors: violations,
+	}
+}
+
+// WriteProblem writes the given violations to the given http.ResponseWriter as a Problem, with the
+// 422 (Unprocessable Content) status code.
+func WriteProblem(w http.ResponseWriter, violations []validation.ConstraintViolation) error {
+	return NewProblem(http.StatusUnprocessableEntity, violations).Write(w)
+}
+
+// Write writes this Problem to the given http.ResponseWriter, using its Status as the status code of
+// the response (or 400 if it isn't set).
+func (p *Problem) Write(w http.ResponseWriter) error {
+	status := p.Status
+	if status == 0 {
+		status = http.StatusBadRequest
+	}
+
+	bs, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+
+	_, err = w.Write(bs)
+	return err
+}
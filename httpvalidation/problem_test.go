@@ -0,0 +1,55 @@
+package httpvalidation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testViolations() []validation.ConstraintViolation {
+	return []validation.ConstraintViolation{
+		{
+			Path:     ".name",
+			Segments: []validation.PathSegment{validation.FieldSegment("Name", "name")},
+			Code:     "required",
+			Message:  "a value is required",
+		},
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	t.Run("should write a problem+json response with the violations", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		require.NoError(t, WriteProblem(rec, testViolations()))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "Unprocessable Entity", body["title"])
+		assert.Equal(t, float64(http.StatusUnprocessableEntity), body["status"])
+		assert.Equal(t, "validation failed: .name: a value is required", body["detail"])
+		assert.NotContains(t, body, "type")
+
+		errs, ok := body["errors"].([]any)
+		require.True(t, ok)
+		require.Len(t, errs, 1)
+		assert.Equal(t, ".name", errs[0].(map[string]any)["path"])
+	})
+}
+
+func TestProblem_Write(t *testing.T) {
+	t.Run("should default to a bad request status", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		require.NoError(t, (&Problem{Title: "Bad Request"}).Write(rec))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.JSONEq(t, `{"title": "Bad Request"}`, rec.Body.String())
+	})
+}
@@ -0,0 +1,282 @@
+// Package i18n provides a message catalog that can be used to translate the messages of violations
+// into different locales, keyed by the code of each violation. English messages for the built-in
+// constraints are bundled, and can be overridden, or added to with other locales.
+//
+// Because messages are looked up by code, custom messages (e.g. those given to constraints.Details)
+// are replaced by the message for the code of the violation, if there is one. Give such violations
+// their own code using constraints.Code, and add messages for that code to translate them.
+package i18n
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/seeruk/go-validation"
+)
+
+// DefaultLocale is the locale that is used if a message can't be found in any requested locale.
+// The bundled English messages are registered under this locale.
+const DefaultLocale = "en"
+
+// Message is a message template for a single violation code, in a single locale. Templates can
+// reference the details of the violation being translated using placeholders, e.g. "{minimum}",
+// see validation.RenderTemplate. The path of the violation can be referenced using "{path}".
+type Message struct {
+	// Other is the template used when no other plural form applies, and the only template used if
+	// Count is empty. It must always be set.
+	Other string
+	// Zero, One, Two, Few, and Many are the templates used for each plural form, as chosen by the
+	// locale's PluralRule. If a form's template is empty, Other is used instead.
+	Zero string
+	One  string
+	Two  string
+	Few  string
+	Many string
+	// Count is the name of the detail used to choose a plural form, e.g. "minimum".
+	Count string
+}
+
+// Messages is a set of messages for a single locale, keyed by violation code.
+type Messages map[string]Message
+
+// Catalog holds messages for any number of locales, along with the information needed to choose
+// between them. A Catalog is safe for concurrent use, though it's expected that it'll usually be
+// configured once, and then used to create a Translator for each request.
+type Catalog struct {
+	mu          sync.RWMutex
+	messages    map[string]Messages
+	fallbacks   map[string][]string
+	pluralRules map[string]PluralRule
+}
+
+// NewCatalog returns a new Catalog, with the bundled English messages registered under
+// DefaultLocale.
+func NewCatalog() *Catalog {
+	c := &Catalog{
+		messages:    make(map[string]Messages),
+		fallbacks:   make(map[string][]string),
+		pluralRules: make(map[string]PluralRule),
+	}
+
+	c.Add(DefaultLocale, English)
+
+	return c
+}
+
+// Add registers the given messages for the given locale, replacing any existing messages for the
+// same codes. This can be used to add new locales, or to override some bundled messages.
+func (c *Catalog) Add(locale string, messages Messages) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	locale = normaliseLocale(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(Messages, len(messages))
+	}
+
+	for code, message := range messages {
+		c.messages[locale][code] = message
+	}
+}
+
+// SetFallbacks sets the locales that are tried, in order, if a message can't be found in the given
+// locale. Locales already fall back to their parent locales (e.g. "pt-BR" to "pt") and then to
+// DefaultLocale; explicit fallbacks are tried after the parent locales, before DefaultLocale.
+func (c *Catalog) SetFallbacks(locale string, fallbacks ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	normalised := make([]string, 0, len(fallbacks))
+	for _, fallback := range fallbacks {
+		normalised = append(normalised, normaliseLocale(fallback))
+	}
+
+	c.fallbacks[normaliseLocale(locale)] = normalised
+}
+
+// SetPluralRule sets the PluralRule used to choose plural forms for the given locale, and any of
+// its child locales that don't have their own rule. Rules for many common languages are built-in.
+func (c *Catalog) SetPluralRule(locale string, rule PluralRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pluralRules[normaliseLocale(locale)] = rule
+}
+
+// Translate returns the message for the given violation in the first of the given locales (or
+// their fallbacks) that has a message for the violation's code, rendered using the violation's
+// details. Messages that reference details the violation doesn't have are skipped, as the violation
+// can't be what they describe. If no message is found, or the violation has a custom message (see
+// validation.ConstraintViolation's Custom field), false is returned.
+func (c *Catalog) Translate(violation validation.ConstraintViolation, locales ...string) (string, bool) {
+	if violation.Code == "" || violation.Custom {
+		return "", false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, locale := range c.chain(locales) {
+		message, ok := c.messages[locale][violation.Code]
+		if !ok {
+			continue
+		}
+
+		if translated, ok := render(c.template(locale, message, violation), violation); ok {
+			return translated, true
+		}
+	}
+
+	return "", false
+}
+
+// Translator returns a validation.Translator that translates violations into the first of the
+// given locales that has a message for each violation (e.g. in the order of preference from an
+// Accept-Language header), falling back as described on SetFallbacks.
+func (c *Catalog) Translator(locales ...string) validation.Translator {
+	return validation.TranslatorFunc(func(violation validation.ConstraintViolation) (string, bool) {
+		return c.Translate(violation, locales...)
+	})
+}
+
+// chain returns the full list of locales to look for messages in, for the given locales, in order,
+// without duplicates.
+func (c *Catalog) chain(locales []string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+
+	var visit func(locale string)
+	visit = func(locale string) {
+		if locale == "" || seen[locale] {
+			return
+		}
+
+		seen[locale] = true
+		chain = append(chain, locale)
+
+		for _, parent := range parentLocales(locale) {
+			visit(parent)
+		}
+
+		for _, fallback := range c.fallbacks[locale] {
+			visit(fallback)
+		}
+	}
+
+	for _, locale := range locales {
+		visit(normaliseLocale(locale))
+	}
+
+	visit(DefaultLocale)
+
+	return chain
+}
+
+// template returns the template of the given message that should be used for the given violation,
+// choosing a plural form if the message has a Count.
+func (c *Catalog) template(locale string, message Message, violation validation.ConstraintViolation) string {
+	if message.Count == "" {
+		return message.Other
+	}
+
+	count, ok := toCount(violation.Details[message.Count])
+	if !ok {
+		return message.Other
+	}
+
+	var template string
+	switch c.pluralRule(locale)(count) {
+	case PluralZero:
+		template = message.Zero
+	case PluralOne:
+		template = message.One
+	case PluralTwo:
+		template = message.Two
+	case PluralFew:
+		template = message.Few
+	case PluralMany:
+		template = message.Many
+	}
+
+	if template == "" {
+		return message.Other
+	}
+
+	return template
+}
+
+// pluralRule returns the PluralRule for the given locale, checking its parent locales, and then
+// the built-in rules, before falling back to the English rule.
+func (c *Catalog) pluralRule(locale string) PluralRule {
+	for _, candidate := range append([]string{locale}, parentLocales(locale)...) {
+		if rule, ok := c.pluralRules[candidate]; ok {
+			return rule
+		}
+
+		if rule, ok := builtinPluralRules[candidate]; ok {
+			return rule
+		}
+	}
+
+	return PluralRuleOneOther
+}
+
+// normaliseLocale returns the given locale in a consistent format, e.g. "pt_br" becomes "pt-br",
+// so that locales can be compared.
+func normaliseLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// parentLocales returns the parents of the given (normalised) locale, most specific first, e.g.
+// "zh-hant-tw" has the parents "zh-hant", and "zh".
+func parentLocales(locale string) []string {
+	var parents []string
+	for {
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			return parents
+		}
+
+		locale = locale[:i]
+		parents = append(parents, locale)
+	}
+}
+
+// render renders the given template using the details of the given violation as params, see
+// validation.RenderTemplate. The path of the violation is available as "{path}", unless there's a
+// detail with the same name. If the template references a param that's missing, false is returned.
+func render(template string, violation validation.ConstraintViolation) (string, bool) {
+	params := make(map[string]any, len(violation.Details)+1)
+	params["path"] = violation.Path
+
+	for key, value := range violation.Details {
+		params[key] = value
+	}
+
+	for _, name := range validation.TemplateParams(template) {
+		if _, ok := params[name]; !ok {
+			return "", false
+		}
+	}
+
+	return validation.RenderTemplate(template, params), true
+}
+
+// toCount returns the given detail value as a count, used to choose a plural form. Details may be
+// any numeric type, depending on where the violation came from (e.g. float64 if it's been decoded
+// from JSON, or ProtoBuf).
+func toCount(value any) (float64, bool) {
+	rval := reflect.ValueOf(value)
+
+	switch rval.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rval.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rval.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rval.Float(), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,247 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCatalog(t *testing.T) {
+	t.Run("should include the English messages", func(t *testing.T) {
+		violations := validation.Validate("", constraints.Required)
+
+		message, ok := NewCatalog().Translate(violations[0])
+		assert.True(t, ok)
+		assert.Equal(t, "a value is required", message)
+	})
+}
+
+func TestCatalog_Add(t *testing.T) {
+	t.Run("should override existing messages", func(t *testing.T) {
+		catalog := NewCatalog()
+		catalog.Add("en", Messages{
+			constraints.CodeRequired: {Other: "please provide a value"},
+		})
+
+		message, ok := catalog.Translate(validation.ConstraintViolation{Code: constraints.CodeRequired})
+		assert.True(t, ok)
+		assert.Equal(t, "please provide a value", message)
+	})
+}
+
+func TestCatalog_Translate(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Add("fr", Messages{
+		constraints.CodeRequired: {Other: "une valeur est requise"},
+		constraints.CodeMinLength: {
+			One:   "doit contenir au moins {minimum} élément",
+			Other: "doit contenir au moins {minimum} éléments",
+			Count: "minimum",
+		},
+	})
+	catalog.Add("fr-CA", Messages{
+		constraints.CodeRequired: {Other: "une valeur est obligatoire"},
+	})
+	catalog.Add("de", Messages{
+		constraints.CodeRequired: {Other: "ein Wert ist erforderlich"},
+	})
+
+	required := validation.ConstraintViolation{Code: constraints.CodeRequired, Message: "a value is required"}
+
+	t.Run("should return false for violations without a code", func(t *testing.T) {
+		_, ok := catalog.Translate(validation.ConstraintViolation{Message: "custom"}, "fr")
+		assert.False(t, ok)
+	})
+
+	t.Run("should return false for unknown codes", func(t *testing.T) {
+		_, ok := catalog.Translate(validation.ConstraintViolation{Code: "unknown"}, "fr")
+		assert.False(t, ok)
+	})
+
+	t.Run("should use the first locale with a message", func(t *testing.T) {
+		message, _ := catalog.Translate(required, "es", "de", "fr")
+		assert.Equal(t, "ein Wert ist erforderlich", message)
+	})
+
+	t.Run("should fall back to parent locales", func(t *testing.T) {
+		message, _ := catalog.Translate(required, "fr_FR")
+		assert.Equal(t, "une valeur est requise", message)
+
+		message, _ = catalog.Translate(required, "fr-ca")
+		assert.Equal(t, "une valeur est obligatoire", message)
+	})
+
+	t.Run("should fall back to the default locale", func(t *testing.T) {
+		message, _ := catalog.Translate(required, "es")
+		assert.Equal(t, "a value is required", message)
+
+		message, _ = catalog.Translate(validation.ConstraintViolation{Code: constraints.CodeNotNil}, "fr")
+		assert.Equal(t, "value must not be nil", message)
+	})
+
+	t.Run("should use explicit fallbacks after parent locales", func(t *testing.T) {
+		catalog := NewCatalog()
+		catalog.Add("de", Messages{
+			constraints.CodeRequired: {Other: "ein Wert ist erforderlich"},
+		})
+		catalog.SetFallbacks("lb", "de")
+
+		message, _ := catalog.Translate(required, "lb-LU")
+		assert.Equal(t, "ein Wert ist erforderlich", message)
+	})
+
+	t.Run("should render details and the path into the message", func(t *testing.T) {
+		catalog := NewCatalog()
+		catalog.Add("en", Messages{
+			"custom": {Other: "{path} must be between {minimum} and {maximum}"},
+		})
+
+		message, _ := catalog.Translate(validation.ConstraintViolation{
+			Path:    ".age",
+			Code:    "custom",
+			Details: map[string]any{"minimum": 18, "maximum": 65.5},
+		})
+
+		assert.Equal(t, ".age must be between 18 and 65.5", message)
+	})
+
+	t.Run("should not use messages that reference missing details", func(t *testing.T) {
+		catalog := NewCatalog()
+		catalog.Add("fr", Messages{
+			constraints.CodeMin: {Other: "doit être au moins {minimum}"},
+		})
+
+		message, ok := catalog.Translate(validation.ConstraintViolation{Code: constraints.CodeMin}, "fr")
+		assert.False(t, ok)
+		assert.Empty(t, message)
+
+		// Fallbacks are still used if their messages can be rendered.
+		catalog.Add("en", Messages{
+			constraints.CodeMin: {Other: "too small"},
+		})
+
+		message, _ = catalog.Translate(validation.ConstraintViolation{Code: constraints.CodeMin}, "fr")
+		assert.Equal(t, "too small", message)
+	})
+
+	t.Run("should not translate custom messages", func(t *testing.T) {
+		_, ok := catalog.Translate(validation.ConstraintViolation{Code: constraints.CodeRequired, Custom: true})
+		assert.False(t, ok)
+	})
+
+	t.Run("should choose plural forms using the locale's plural rule", func(t *testing.T) {
+		violation := func(minimum any) validation.ConstraintViolation {
+			return validation.ConstraintViolation{
+				Code:    constraints.CodeMinLength,
+				Details: map[string]any{"minimum": minimum},
+			}
+		}
+
+		message, _ := catalog.Translate(violation(0), "fr")
+		assert.Equal(t, "doit contenir au moins 0 élément", message)
+
+		message, _ = catalog.Translate(violation(float64(3)), "fr")
+		assert.Equal(t, "doit contenir au moins 3 éléments", message)
+
+		message, _ = catalog.Translate(validation.ConstraintViolation{
+			Code:    constraints.CodeAtLeastNRequired,
+			Details: map[string]any{"minimum": uint(1)},
+		}, "en")
+		assert.Equal(t, "at least 1 field is required", message)
+	})
+
+	t.Run("should only treat zero as one in Brazilian Portuguese", func(t *testing.T) {
+		catalog := NewCatalog()
+		for _, locale := range []string{"pt", "pt-BR"} {
+			catalog.Add(locale, Messages{
+				"count": {One: "one", Other: "other", Count: "n"},
+			})
+		}
+
+		violation := validation.ConstraintViolation{Code: "count", Details: map[string]any{"n": 0}}
+
+		message, _ := catalog.Translate(violation, "pt-BR")
+		assert.Equal(t, "one", message)
+
+		message, _ = catalog.Translate(violation, "pt")
+		assert.Equal(t, "other", message)
+	})
+
+	t.Run("should use the other form if the count detail is missing", func(t *testing.T) {
+		catalog := NewCatalog()
+		catalog.Add("en", Messages{
+			"count": {One: "one", Other: "other", Count: "n"},
+		})
+
+		message, _ := catalog.Translate(validation.ConstraintViolation{Code: "count"})
+		assert.Equal(t, "other", message)
+	})
+
+	t.Run("should use custom plural rules", func(t *testing.T) {
+		catalog := NewCatalog()
+		catalog.Add("xx", Messages{
+			"count": {Two: "two", Other: "other", Count: "n"},
+		})
+		catalog.SetPluralRule("xx", func(n float64) PluralForm {
+			if n == 2 {
+				return PluralTwo
+			}
+
+			return PluralOther
+		})
+
+		message, _ := catalog.Translate(validation.ConstraintViolation{Code: "count", Details: map[string]any{"n": 2}}, "xx-YY")
+		assert.Equal(t, "two", message)
+	})
+}
+
+func TestCatalog_Translator(t *testing.T) {
+	t.Run("should translate violations on a Context", func(t *testing.T) {
+		catalog := NewCatalog()
+		catalog.Add("de", Messages{
+			constraints.CodeRequired: {Other: "ein Wert ist erforderlich"},
+		})
+
+		ctx := validation.NewContext("", validation.WithTranslator(catalog.Translator("de-AT")))
+
+		violations := validation.ValidateContext(ctx, constraints.Required)
+		assert.Equal(t, "ein Wert ist erforderlich", violations[0].Message)
+	})
+
+	t.Run("should keep the messages of Details and Template", func(t *testing.T) {
+		ctx := validation.NewContext(1, validation.WithTranslator(NewCatalog().Translator("en")))
+
+		violations := validation.ValidateContext(ctx,
+			constraints.Details(constraints.Min(3), "you must be at least three"),
+			constraints.Template(constraints.Min(5), "you must be at least {minimum}"),
+		)
+
+		require.Len(t, violations, 2)
+		assert.Equal(t, "you must be at least three", violations[0].Message)
+		assert.Equal(t, "you must be at least 5", violations[1].Message)
+	})
+}
+
+func TestEnglish(t *testing.T) {
+	t.Run("should render the messages of the built-in constraints", func(t *testing.T) {
+		ctx := validation.NewContext(struct{ A, B, C string }{C: "c"}, validation.WithTranslator(NewCatalog().Translator()))
+
+		violations := validation.ValidateContext(ctx,
+			constraints.AtLeastNRequired(2, "A", "B", "C"),
+			validation.Fields{
+				"A": constraints.MinLength(3),
+				"B": constraints.Required,
+			},
+		)
+
+		var messages []string
+		for _, violation := range violations {
+			messages = append(messages, violation.Message)
+		}
+
+		assert.Equal(t, []string{"at least 2 fields are required", "a value is required"}, messages)
+	})
+}
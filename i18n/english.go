@@ -0,0 +1,105 @@
+package i18n
+
+import (
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+)
+
+// English holds the bundled English messages for the violations produced by the validation and
+// constraints packages. These are registered under DefaultLocale by NewCatalog. There's no message
+// for constraints.CodeNoError, because its message is always given by the caller.
+var English = Messages{
+	validation.CodeCancelled: {
+		Other: "validation was cancelled",
+	},
+	validation.CodeInvalidJSON: {
+		Other: "value is not valid JSON: {error}",
+	},
+	validation.CodeInvalidType: {
+		Other: "value must be of type {expected}",
+	},
+	validation.CodeUnknownField: {
+		Other: "unknown field",
+	},
+	constraints.CodeAtLeastNRequired: {
+		One:   "at least {minimum} field is required",
+		Other: "at least {minimum} fields are required",
+		Count: "minimum",
+	},
+	constraints.CodeAtMostNRequired: {
+		One:   "at most {maximum} field may be provided",
+		Other: "at most {maximum} fields may be provided",
+		Count: "maximum",
+	},
+	constraints.CodeEmpty: {
+		Other: "a value must not be provided",
+	},
+	constraints.CodeEquals: {
+		Other: "value must equal {expected}",
+	},
+	constraints.CodeExactlyNRequired: {
+		One:   "exactly {expected} field is required",
+		Other: "exactly {expected} fields are required",
+		Count: "expected",
+	},
+	constraints.CodeExactlyOneSet: {
+		Other: "exactly one field must be set",
+	},
+	constraints.CodeKind: {
+		Other: "value should be of one of the allowed kinds",
+	},
+	constraints.CodeLength: {
+		Other: "length must be exactly {expected}",
+	},
+	constraints.CodeMax: {
+		Other: "value must be at most {maximum}",
+	},
+	constraints.CodeMaxLength: {
+		Other: "length must be at most {maximum}",
+	},
+	constraints.CodeMin: {
+		Other: "value must be at least {minimum}",
+	},
+	constraints.CodeMinLength: {
+		Other: "length must be at least {minimum}",
+	},
+	constraints.CodeMutuallyExclusive: {
+		Other: "fields are mutually exclusive",
+	},
+	constraints.CodeMutuallyInclusive: {
+		Other: "fields are mutually inclusive",
+	},
+	constraints.CodeNil: {
+		Other: "value must be nil",
+	},
+	constraints.CodeNoneOf: {
+		Other: "value must not be one of the disallowed values",
+	},
+	constraints.CodeNotEquals: {
+		Other: "value must not equal {expected}",
+	},
+	constraints.CodeNotNil: {
+		Other: "value must not be nil",
+	},
+	constraints.CodeOneOf: {
+		Other: "value must be one of the allowed values",
+	},
+	constraints.CodeOneOfKeys: {
+		Other: "key must be one of the allowed keys",
+	},
+	constraints.CodeRegexp: {
+		Other: "value must match regular expression",
+	},
+	constraints.CodeRequired: {
+		Other: "a value is required",
+	},
+	constraints.CodeTimeAfter: {
+		Other: "value must be after {time}",
+	},
+	constraints.CodeTimeBefore: {
+		Other: "value must be before {time}",
+	},
+	constraints.CodeType: {
+		Other: "value does not match expected type",
+	},
+}
@@ -0,0 +1,106 @@
+package i18n
+
+import "math"
+
+// All possible PluralForm values, as defined by the Unicode CLDR.
+const (
+	PluralOther PluralForm = iota
+	PluralZero
+	PluralOne
+	PluralTwo
+	PluralFew
+	PluralMany
+)
+
+// PluralForm enumerates the plural forms a message may have. Which forms are used, and when, varies
+// between languages (e.g. English only uses PluralOne and PluralOther).
+type PluralForm int
+
+// PluralRule chooses the PluralForm to use for the given count in a particular language.
+type PluralRule func(n float64) PluralForm
+
+// PluralRuleOneOther is the PluralRule for languages that only distinguish between one and
+// everything else, like English, German, Dutch, Italian, and Spanish.
+func PluralRuleOneOther(n float64) PluralForm {
+	if n == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+// PluralRuleOther is the PluralRule for languages that don't have plural forms, like Chinese,
+// Japanese, and Korean.
+func PluralRuleOther(float64) PluralForm {
+	return PluralOther
+}
+
+// PluralRuleFrench is the PluralRule for languages that treat zero the same as one, like French
+// and Brazilian Portuguese.
+func PluralRuleFrench(n float64) PluralForm {
+	if n == 0 || n == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+// PluralRuleSlavic is the PluralRule for East Slavic languages like Russian and Ukrainian, which
+// have distinct forms for numbers ending in one, numbers ending in two to four, and the rest.
+func PluralRuleSlavic(n float64) PluralForm {
+	if n != math.Trunc(n) {
+		return PluralOther
+	}
+
+	mod10, mod100 := math.Mod(n, 10), math.Mod(n, 100)
+
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// PluralRulePolish is the PluralRule for Polish, which is like PluralRuleSlavic, except only
+// exactly one uses the "one" form.
+func PluralRulePolish(n float64) PluralForm {
+	if n != math.Trunc(n) {
+		return PluralOther
+	}
+
+	mod10, mod100 := math.Mod(n, 10), math.Mod(n, 100)
+
+	switch {
+	case n == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// builtinPluralRules holds the PluralRule of each language that's supported out of the box, keyed
+// by (normalised) locale. Locales that aren't here use the rule of their parent locale, if it is,
+// or PluralRuleOneOther, unless configured otherwise. Only Brazilian Portuguese treats zero the
+// same as one, so other Portuguese locales use the English rule.
+var builtinPluralRules = map[string]PluralRule{
+	"en":    PluralRuleOneOther,
+	"de":    PluralRuleOneOther,
+	"es":    PluralRuleOneOther,
+	"it":    PluralRuleOneOther,
+	"nl":    PluralRuleOneOther,
+	"sv":    PluralRuleOneOther,
+	"fr":    PluralRuleFrench,
+	"pt":    PluralRuleOneOther,
+	"pt-br": PluralRuleFrench,
+	"ru":    PluralRuleSlavic,
+	"uk":    PluralRuleSlavic,
+	"pl":    PluralRulePolish,
+	"ja":    PluralRuleOther,
+	"ko":    PluralRuleOther,
+	"zh":    PluralRuleOther,
+}
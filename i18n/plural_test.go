@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluralRules(t *testing.T) {
+	tt := []struct {
+		name     string
+		rule     PluralRule
+		expected map[float64]PluralForm
+	}{
+		{
+			name: "one other",
+			rule: PluralRuleOneOther,
+			expected: map[float64]PluralForm{
+				0: PluralOther, 1: PluralOne, 2: PluralOther, 1.5: PluralOther,
+			},
+		},
+		{
+			name: "other",
+			rule: PluralRuleOther,
+			expected: map[float64]PluralForm{
+				0: PluralOther, 1: PluralOther, 2: PluralOther,
+			},
+		},
+		{
+			name: "french",
+			rule: PluralRuleFrench,
+			expected: map[float64]PluralForm{
+				0: PluralOne, 1: PluralOne, 2: PluralOther,
+			},
+		},
+		{
+			name: "slavic",
+			rule: PluralRuleSlavic,
+			expected: map[float64]PluralForm{
+				1: PluralOne, 2: PluralFew, 5: PluralMany, 11: PluralMany, 12: PluralMany, 21: PluralOne,
+				22: PluralFew, 1.5: PluralOther,
+			},
+		},
+		{
+			name: "polish",
+			rule: PluralRulePolish,
+			expected: map[float64]PluralForm{
+				1: PluralOne, 2: PluralFew, 5: PluralMany, 12: PluralMany, 21: PluralMany, 22: PluralFew,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run("should choose the right forms for "+tc.name, func(t *testing.T) {
+			for n, form := range tc.expected {
+				assert.Equal(t, form, tc.rule(n), "n = %v", n)
+			}
+		})
+	}
+}
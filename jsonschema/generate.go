@@ -0,0 +1,553 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+)
+
+// Unsupported is a constraint that has no JSON Schema equivalent, and so was left out of a
+// generated schema.
+type Unsupported struct {
+	// Path is a JSON Pointer to the subschema of the value that the constraint applies to, within
+	// the generated schema, e.g. "#/properties/name".
+	Path string
+	// Code is the code of the rule enforced by the constraint, if it describes itself (see
+	// validation.Describe).
+	Code string
+	// Constraint is the constraint itself.
+	Constraint validation.Constraint
+}
+
+// String returns a description of this Unsupported constraint, for use in errors.
+func (u Unsupported) String() string {
+	if u.Code != "" {
+		return fmt.Sprintf("%s (%s)", u.Path, u.Code)
+	}
+
+	return fmt.Sprintf("%s (%T)", u.Path, u.Constraint)
+}
+
+// UnsupportedError is returned by Generate if any constraints have no JSON Schema equivalent.
+type UnsupportedError struct {
+	Unsupported []Unsupported
+}
+
+// Error returns the error message of this UnsupportedError, listing the unsupported constraints.
+func (e *UnsupportedError) Error() string {
+	descriptions := make([]string, 0, len(e.Unsupported))
+	for _, u := range e.Unsupported {
+		descriptions = append(descriptions, u.String())
+	}
+
+	return fmt.Sprintf("jsonschema: %d constraint(s) have no JSON Schema equivalent: %s", len(e.Unsupported), strings.Join(descriptions, ", "))
+}
+
+// For returns a JSON Schema document for values of type T, see Generate.
+func For[T any](constraint validation.Constraint) (*Schema, error) {
+	return Generate(reflect.TypeOf((*T)(nil)).Elem(), constraint)
+}
+
+// Generate returns a JSON Schema document for values of the given type, that are validated using
+// the given constraint. If the constraint is nil, and the type implements validation.Validatable,
+// its constraints are used instead.
+//
+// Constraints, Fields, OrderedFields, Elements, Keys, Map, and Lazy are followed to the values they
+// apply to. Constraints that wrap others, like Sequence, or constraints.Code, are followed to the
+// constraints they wrap. Other constraints are described without being applied (see
+// validation.Describer), and added to the schema of the value they apply to as follows:
+//
+//   - Required: "required" on the parent object, and a non-empty value (see the package docs).
+//   - Min and Max: "minimum", and "maximum".
+//   - MinLength, MaxLength, and Length: "minLength", and "maxLength" for strings, "minItems", and
+//     "maxItems" for arrays, and "minProperties", and "maxProperties" for objects.
+//   - Regexp: "pattern".
+//   - OneOf and NoneOf: "enum", and "not" an "enum".
+//   - Equals and NotEquals: "const", and "not" a "const".
+//   - MutuallyExclusive, AtLeastNRequired, AtMostNRequired, and ExactlyNRequired: "anyOf", and
+//     "not" an "anyOf", the combinations of properties that are (or aren't) allowed to be present.
+//
+// Constraints that have no equivalent (including those that can't describe themselves, like custom
+// ConstraintFuncs, those that are only applied conditionally, like When, or those in groups) are
+// left out of the schema, and are returned in an *UnsupportedError, along with the schema, so that
+// the schema can still be used if they're expected.
+func Generate(typ reflect.Type, constraint validation.Constraint) (*Schema, error) {
+	if constraint == nil {
+		constraint = validatableConstraints(typ)
+	}
+
+	g := &generator{}
+
+	schema := g.typeSchema(typ)
+	schema.Schema = Dialect
+
+	if constraint != nil {
+		g.apply(location{schema: schema, typ: typ, path: "#"}, constraint)
+	}
+
+	if len(g.unsupported) > 0 {
+		return schema, &UnsupportedError{Unsupported: g.unsupported}
+	}
+
+	return schema, nil
+}
+
+// generator holds the state of a schema being generated.
+type generator struct {
+	// types are the struct types whose schemas are being generated, see typeSchema.
+	types []reflect.Type
+	// constraining are the struct types whose fields constraints are being applied to, so that
+	// recursive constraints (i.e. those using Lazy) don't recurse forever.
+	constraining []reflect.Type
+	// unsupported are the constraints found so far that have no JSON Schema equivalent.
+	unsupported []Unsupported
+}
+
+// location is the (sub)schema of a value that constraints are being applied to.
+type location struct {
+	schema *Schema
+	typ    reflect.Type
+	path   string
+	// parent is the schema of the object that the value is a property of, if it's a property of
+	// one, and name is the name of that property.
+	parent *Schema
+	name   string
+}
+
+// child returns the location of the value with the given subschema, beneath this location.
+func (l location) child(schema *Schema, typ reflect.Type, path ...string) location {
+	for i := range path {
+		path[i] = validation.EscapeJSONPointer(path[i])
+	}
+
+	return location{
+		schema: schema,
+		typ:    typ,
+		path:   l.path + "/" + strings.Join(path, "/"),
+	}
+}
+
+// property returns the location of the object property with the given name, beneath this location,
+// creating its schema if needed.
+func (l location) property(g *generator, name string, typ reflect.Type) location {
+	schema, ok := l.schema.Properties[name]
+	if !ok {
+		schema = g.typeSchema(typ)
+
+		if l.schema.Properties == nil {
+			l.schema.Properties = make(map[string]*Schema)
+		}
+
+		l.schema.Properties[name] = schema
+	}
+
+	loc := l.child(schema, typ, "properties", name)
+	loc.parent = l.schema
+	loc.name = name
+
+	return loc
+}
+
+// kind returns the kind of the value at this location, unwrapping pointers.
+func (l location) kind() reflect.Kind {
+	return validation.UnwrapType(l.typ).Kind()
+}
+
+// apply adds the given constraint to the schema at the given location.
+func (g *generator) apply(loc location, constraint validation.Constraint) {
+	switch c := constraint.(type) {
+	case validation.Constraints:
+		for _, c := range c {
+			g.apply(loc, c)
+		}
+	case validation.Fields:
+		names := make([]string, 0, len(c))
+		for name := range c {
+			names = append(names, name)
+		}
+
+		// Fields is a map, so the fields are sorted to generate the same schema every time.
+		slices.Sort(names)
+
+		fields := make(validation.OrderedFields, 0, len(names))
+		for _, name := range names {
+			fields = append(fields, validation.OrderedField{Name: name, Constraint: c[name]})
+		}
+
+		g.applyFields(loc, c, fields)
+	case validation.OrderedFields:
+		g.applyFields(loc, c, c)
+	case validation.Elements:
+		switch {
+		case customEncoding(validation.UnwrapType(loc.typ)):
+			// The elements of values with custom encodings have no schema of their own.
+			g.unsupport(loc, "", c)
+		case loc.kind() == reflect.Map:
+			typ := validation.UnwrapType(loc.typ)
+			g.apply(loc.child(loc.schema.AdditionalProperties, typ.Elem(), "additionalProperties"), validation.Constraints(c))
+		case (loc.kind() == reflect.Slice || loc.kind() == reflect.Array) && !isBytes(validation.UnwrapType(loc.typ)):
+			typ := validation.UnwrapType(loc.typ)
+			g.apply(loc.child(loc.schema.Items, typ.Elem(), "items"), validation.Constraints(c))
+		default:
+			g.unsupport(loc, "", c)
+		}
+	case validation.Keys:
+		typ := validation.UnwrapType(loc.typ)
+		if typ.Kind() != reflect.Map || typ.Key().Kind() != reflect.String || customEncoding(typ) {
+			// Keys of other types are converted to strings, which their constraints don't apply to.
+			g.unsupport(loc, "", c)
+			return
+		}
+
+		if loc.schema.PropertyNames == nil {
+			loc.schema.PropertyNames = &Schema{Type: "string"}
+		}
+
+		g.apply(loc.child(loc.schema.PropertyNames, typ.Key(), "propertyNames"), validation.Constraints(c))
+	case validation.Map:
+		typ := validation.UnwrapType(loc.typ)
+		if typ.Kind() != reflect.Map || customEncoding(typ) {
+			g.unsupport(loc, "", c)
+			return
+		}
+
+		keys := make([]string, 0, len(c))
+		constraintsByKey := make(map[string]validation.Constraint, len(c))
+		for key, constraint := range c {
+			keys = append(keys, fmt.Sprint(key))
+			constraintsByKey[fmt.Sprint(key)] = constraint
+		}
+
+		slices.Sort(keys)
+
+		// The values of maps are described by "additionalProperties", which doesn't apply to any
+		// "properties" alongside it, so specific keys are described in a subschema instead.
+		keysSchema := &Schema{}
+		loc.schema.addAllOf(keysSchema)
+
+		keysLoc := loc.child(keysSchema, loc.typ, "allOf", strconv.Itoa(len(loc.schema.AllOf)-1))
+		for _, key := range keys {
+			g.apply(keysLoc.property(g, key, typ.Elem()), constraintsByKey[key])
+		}
+	case validation.Lazy:
+		g.apply(loc, c())
+	case validation.Describer:
+		g.applyDescribed(loc, c)
+	default:
+		// There's no telling what any other constraint does, so it can't be described.
+		g.unsupport(loc, "", c)
+	}
+}
+
+// applyFields adds the constraints of the given fields to the schemas of the properties they're
+// encoded as, on the struct schema at the given location. The given constraint is the one the
+// fields are from, which is reported if they can't be applied.
+func (g *generator) applyFields(loc location, constraint validation.Constraint, fields validation.OrderedFields) {
+	typ := validation.UnwrapType(loc.typ)
+	if typ.Kind() != reflect.Struct || slices.Contains(g.constraining, typ) {
+		g.unsupport(loc, "", constraint)
+		return
+	}
+
+	g.constraining = append(g.constraining, typ)
+	defer func() {
+		g.constraining = g.constraining[:len(g.constraining)-1]
+	}()
+
+	for _, field := range fields {
+		jf, ok := lookupJSONField(typ, field.Name)
+		if !ok || jf.quoted {
+			// The constraints apply to a value that isn't encoded, or isn't encoded as itself.
+			g.unsupport(loc, "", field.Constraint)
+			continue
+		}
+
+		g.apply(loc.property(g, jf.name, jf.field.Type), field.Constraint)
+	}
+}
+
+// applyDescribed adds the rules the given constraint describes itself as enforcing, and the
+// constraints whose rules it enforces, to the schema at the given location.
+func (g *generator) applyDescribed(loc location, constraint validation.Describer) {
+	descriptions, children := constraint.Describe()
+	if len(descriptions) == 0 && len(children) == 0 {
+		g.unsupport(loc, "", constraint)
+		return
+	}
+
+	for _, description := range descriptions {
+		if !g.applyDescription(loc, description) {
+			g.unsupport(loc, description.Code, constraint)
+		}
+	}
+
+	for _, child := range children {
+		g.apply(loc, child)
+	}
+}
+
+// applyDescription adds the given rule to the schema at the given location, returning false if it
+// has no JSON Schema equivalent.
+func (g *generator) applyDescription(loc location, description validation.Description) bool {
+	schema := loc.schema
+	params := description.Params
+
+	switch description.Code {
+	case constraints.CodeRequired:
+		applyRequired(loc)
+	case constraints.CodeMin:
+		if !isNumber(loc) {
+			return false
+		}
+
+		minimum := params["minimum"].(float64)
+		if schema.Minimum == nil || *schema.Minimum < minimum {
+			schema.Minimum = &minimum
+		}
+	case constraints.CodeMax:
+		if !isNumber(loc) {
+			return false
+		}
+
+		maximum := params["maximum"].(float64)
+		if schema.Maximum == nil || *schema.Maximum > maximum {
+			schema.Maximum = &maximum
+		}
+	case constraints.CodeMinLength:
+		return applyLength(loc, params["minimum"].(int), -1)
+	case constraints.CodeMaxLength:
+		return applyLength(loc, -1, params["maximum"].(int))
+	case constraints.CodeLength:
+		return applyLength(loc, params["expected"].(int), params["expected"].(int))
+	case constraints.CodeRegexp:
+		if loc.kind() != reflect.String && loc.kind() != reflect.Interface {
+			return false
+		}
+
+		if schema.Pattern == "" {
+			schema.Pattern = params["regexp"].(string)
+		} else {
+			schema.addAllOf(&Schema{Pattern: params["regexp"].(string)})
+		}
+	case constraints.CodeOneOf:
+		if schema.Enum == nil {
+			schema.Enum = values(params["allowed"])
+		} else {
+			schema.addAllOf(&Schema{Enum: values(params["allowed"])})
+		}
+	case constraints.CodeNoneOf:
+		schema.addNot(&Schema{Enum: values(params["disallowed"])})
+	case constraints.CodeEquals:
+		schema.addConst(params["expected"])
+	case constraints.CodeNotEquals:
+		schema.addNot(&Schema{Const: params["expected"]})
+	case constraints.CodeMutuallyExclusive:
+		return applyFieldCount(loc, params["fields"].([]string), 0, 1)
+	case constraints.CodeAtLeastNRequired:
+		return applyFieldCount(loc, params["fields"].([]string), params["minimum"].(int), -1)
+	case constraints.CodeAtMostNRequired:
+		return applyFieldCount(loc, params["fields"].([]string), 0, params["maximum"].(int))
+	case constraints.CodeExactlyNRequired:
+		return applyFieldCount(loc, params["fields"].([]string), params["expected"].(int), params["expected"].(int))
+	default:
+		return false
+	}
+
+	return true
+}
+
+// unsupport records that the given constraint (or the rule with the given code that it enforces)
+// couldn't be added to the schema at the given location.
+func (g *generator) unsupport(loc location, code string, constraint validation.Constraint) {
+	g.unsupported = append(g.unsupported, Unsupported{
+		Path:       loc.path,
+		Code:       code,
+		Constraint: constraint,
+	})
+}
+
+// applyRequired requires the value at the given location to be present, and non-empty.
+func applyRequired(loc location) {
+	if loc.parent != nil {
+		loc.parent.addRequired(loc.name)
+	}
+
+	schema := loc.schema
+
+	// Pointers must not be nil.
+	if types, ok := schema.Type.([]string); ok {
+		schema.Type = types[0]
+	}
+
+	switch loc.kind() {
+	case reflect.Bool:
+		schema.addConst(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		schema.addNot(&Schema{Const: 0})
+	case reflect.String:
+		schema.MinLength = maxInt(schema.MinLength, 1)
+	case reflect.Slice:
+		if isBytes(validation.UnwrapType(loc.typ)) {
+			schema.MinLength = maxInt(schema.MinLength, 1)
+		} else {
+			schema.MinItems = maxInt(schema.MinItems, 1)
+		}
+	case reflect.Map:
+		schema.MinProperties = maxInt(schema.MinProperties, 1)
+	}
+}
+
+// applyLength restricts the length of the value at the given location, returning false if its
+// length can't be restricted. Negative lengths are ignored.
+func applyLength(loc location, minimum, maximum int) bool {
+	schema := loc.schema
+
+	restrict := func(min, max **int) {
+		if minimum >= 0 {
+			*min = maxInt(*min, minimum)
+		}
+
+		if maximum >= 0 {
+			*max = minInt(*max, maximum)
+		}
+	}
+
+	switch loc.kind() {
+	case reflect.String:
+		restrict(&schema.MinLength, &schema.MaxLength)
+	case reflect.Array, reflect.Slice:
+		if isBytes(validation.UnwrapType(loc.typ)) {
+			// The length of the encoded string isn't the length of the value.
+			return false
+		}
+
+		restrict(&schema.MinItems, &schema.MaxItems)
+	case reflect.Map:
+		restrict(&schema.MinProperties, &schema.MaxProperties)
+	case reflect.Interface:
+		// Each of these only applies to values of their own type.
+		restrict(&schema.MinLength, &schema.MaxLength)
+		restrict(&schema.MinItems, &schema.MaxItems)
+		restrict(&schema.MinProperties, &schema.MaxProperties)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// applyFieldCount requires the number of the fields with the given (Go) names that are present on
+// the struct at the given location to be within the given range, returning false if the value isn't
+// a struct, or if any of the fields aren't encoded. A negative maximum means there's no maximum.
+func applyFieldCount(loc location, fields []string, minimum, maximum int) bool {
+	typ := validation.UnwrapType(loc.typ)
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		jf, ok := lookupJSONField(typ, field)
+		if !ok {
+			return false
+		}
+
+		names = append(names, jf.name)
+	}
+
+	if minimum > 0 {
+		loc.schema.addAnyOf(requiredCombinations(names, minimum)...)
+	}
+
+	if maximum >= 0 && maximum < len(names) {
+		loc.schema.addNot(&Schema{AnyOf: requiredCombinations(names, maximum+1)})
+	}
+
+	return true
+}
+
+// requiredCombinations returns a schema requiring each combination of n of the given properties.
+func requiredCombinations(names []string, n int) []*Schema {
+	var schemas []*Schema
+
+	var combine func(start int, combination []string)
+	combine = func(start int, combination []string) {
+		if len(combination) == n {
+			schemas = append(schemas, &Schema{Required: slices.Clone(combination)})
+			return
+		}
+
+		for i := start; i < len(names); i++ {
+			combine(i+1, append(combination, names[i]))
+		}
+	}
+
+	combine(0, make([]string, 0, n))
+
+	return schemas
+}
+
+// validatableConstraints returns the constraints of values of the given type, if it implements
+// validation.Validatable, otherwise nil.
+func validatableConstraints(typ reflect.Type) validation.Constraint {
+	ptr := reflect.New(typ)
+
+	if v, ok := ptr.Elem().Interface().(validation.Validatable); ok && typ.Kind() != reflect.Pointer {
+		return v.Constraints()
+	}
+
+	if v, ok := ptr.Interface().(validation.Validatable); ok {
+		return v.Constraints()
+	}
+
+	return nil
+}
+
+// isNumber returns true if the value at the given location may be a number.
+func isNumber(loc location) bool {
+	switch loc.kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface:
+		return true
+	}
+
+	return false
+}
+
+// values returns the elements of the given slice as a []any, e.g. for "enum".
+func values(slice any) []any {
+	rval := reflect.ValueOf(slice)
+
+	values := make([]any, rval.Len())
+	for i := range values {
+		values[i] = rval.Index(i).Interface()
+	}
+
+	return values
+}
+
+// maxInt returns a pointer to the greater of the given value, and the given limit, if it's set.
+func maxInt(limit *int, value int) *int {
+	if limit != nil && *limit > value {
+		return limit
+	}
+
+	return &value
+}
+
+// minInt returns a pointer to the lesser of the given value, and the given limit, if it's set.
+func minInt(limit *int, value int) *int {
+	if limit != nil && *limit < value {
+		return limit
+	}
+
+	return &value
+}
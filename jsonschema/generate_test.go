@@ -0,0 +1,344 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAddress struct {
+	Street   string `json:"street"`
+	Postcode string `json:"postcode,omitempty"`
+}
+
+type testPerson struct {
+	Name     string            `json:"name"`
+	Age      int               `json:"age"`
+	Email    *string           `json:"email,omitempty"`
+	Phone    string            `json:"phone,omitempty"`
+	Role     string            `json:"role"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Address  testAddress       `json:"address"`
+	Birthday time.Time         `json:"birthday"`
+	Secret   string            `json:"-"`
+}
+
+func (p testPerson) Constraints() validation.Constraint {
+	return validation.Fields{
+		"Name": constraints.Required,
+	}
+}
+
+type testNode struct {
+	Name     string     `json:"name"`
+	Children []testNode `json:"children"`
+}
+
+type testMarshalerSlice []string
+
+func (s testMarshalerSlice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.Join(s, ","))
+}
+
+type testMarshalerMap map[string]string
+
+func (m testMarshalerMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(len(m))
+}
+
+type testMarshalers struct {
+	Slice testMarshalerSlice `json:"slice"`
+	Map   testMarshalerMap   `json:"map"`
+}
+
+func marshal(t *testing.T, schema *Schema) string {
+	bs, err := json.Marshal(schema)
+	require.NoError(t, err)
+
+	return string(bs)
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("should describe the type of the value", func(t *testing.T) {
+		schema, err := For[testAddress](nil)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"street": {"type": "string"},
+				"postcode": {"type": "string"}
+			}
+		}`, marshal(t, schema))
+	})
+
+	t.Run("should describe the types of values as they're encoded as JSON", func(t *testing.T) {
+		type types struct {
+			Bool     bool      `json:"bool"`
+			Uint     uint8     `json:"uint"`
+			Float    float64   `json:"float"`
+			Pointer  *int      `json:"pointer"`
+			Bytes    []byte    `json:"bytes"`
+			Array    [2]string `json:"array"`
+			Time     time.Time `json:"time"`
+			Any      any       `json:"any"`
+			Quoted   int       `json:"quoted,string"`
+			Untagged string
+			Embedded testAddress
+			testAddress
+		}
+
+		schema, err := For[types](nil)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"bool": {"type": "boolean"},
+				"uint": {"type": "integer"},
+				"float": {"type": "number"},
+				"pointer": {"type": ["integer", "null"]},
+				"bytes": {"type": "string", "contentEncoding": "base64"},
+				"array": {"type": "array", "items": {"type": "string"}, "minItems": 2, "maxItems": 2},
+				"time": {"type": "string", "format": "date-time"},
+				"any": {},
+				"quoted": {"type": "string"},
+				"Untagged": {"type": "string"},
+				"Embedded": {
+					"type": "object",
+					"properties": {"street": {"type": "string"}, "postcode": {"type": "string"}}
+				},
+				"street": {"type": "string"},
+				"postcode": {"type": "string"}
+			}
+		}`, marshal(t, schema))
+	})
+
+	t.Run("should add constraints to the schemas of the values they apply to", func(t *testing.T) {
+		schema, err := For[testPerson](validation.Fields{
+			"Name":  validation.Constraints{constraints.Required, constraints.MaxLength(50)},
+			"Age":   validation.Constraints{constraints.Min(18), constraints.Max(130)},
+			"Email": validation.Constraints{constraints.Required, constraints.Regexp(regexp.MustCompile(`^.+@.+$`))},
+			"Role":  validation.Constraints{constraints.OneOf("admin", "user"), constraints.NoneOf("root", "nobody")},
+			"Tags": validation.Constraints{
+				constraints.Length(2),
+				validation.Elements{constraints.MinLength(1)},
+			},
+			"Labels": validation.Constraints{
+				validation.Keys{constraints.Regexp(regexp.MustCompile(`^[a-z]+$`))},
+				validation.Elements{constraints.MaxLength(10)},
+				validation.Map{"env": constraints.Required},
+			},
+			"Address": validation.Fields{
+				"Street": constraints.Required,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"name": {"type": "string", "minLength": 1, "maxLength": 50},
+				"age": {"type": "integer", "minimum": 18, "maximum": 130},
+				"email": {"type": "string", "minLength": 1, "pattern": "^.+@.+$"},
+				"phone": {"type": "string"},
+				"role": {"type": "string", "enum": ["admin", "user"], "not": {"enum": ["root", "nobody"]}},
+				"tags": {"type": "array", "items": {"type": "string", "minLength": 1}, "minItems": 2, "maxItems": 2},
+				"labels": {
+					"type": "object",
+					"additionalProperties": {"type": "string", "maxLength": 10},
+					"propertyNames": {"type": "string", "pattern": "^[a-z]+$"},
+					"allOf": [{"properties": {"env": {"type": "string", "minLength": 1}}, "required": ["env"]}]
+				},
+				"address": {
+					"type": "object",
+					"properties": {"street": {"type": "string", "minLength": 1}, "postcode": {"type": "string"}},
+					"required": ["street"]
+				},
+				"birthday": {"type": "string", "format": "date-time"}
+			},
+			"required": ["email", "name"]
+		}`, marshal(t, schema))
+	})
+
+	t.Run("should describe the number of fields that may be present", func(t *testing.T) {
+		schema, err := For[testPerson](validation.Constraints{
+			constraints.MutuallyExclusive("Email", "Phone"),
+			constraints.AtLeastNRequired(1, "Email", "Phone"),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []*Schema{{Required: []string{"email"}}, {Required: []string{"phone"}}}, schema.AnyOf)
+		assert.Equal(t, &Schema{AnyOf: []*Schema{{Required: []string{"email", "phone"}}}}, schema.Not)
+	})
+
+	t.Run("should combine rules that use the same keyword", func(t *testing.T) {
+		schema, err := For[testAddress](validation.Fields{
+			"Street": validation.Constraints{
+				constraints.Regexp(regexp.MustCompile(`^a`)),
+				constraints.Regexp(regexp.MustCompile(`b$`)),
+				constraints.MinLength(2),
+				constraints.MinLength(5),
+			},
+		})
+		require.NoError(t, err)
+
+		street := schema.Properties["street"]
+		assert.Equal(t, "^a", street.Pattern)
+		assert.Equal(t, []*Schema{{Pattern: "b$"}}, street.AllOf)
+		assert.Equal(t, 5, *street.MinLength)
+	})
+
+	t.Run("should follow OrderedFields, and Lazy constraints", func(t *testing.T) {
+		schema, err := For[testAddress](validation.Lazy(func() validation.Constraint {
+			return validation.OrderedFields{
+				validation.Field("Postcode", constraints.Required),
+				validation.Field("Street", constraints.Required),
+			}
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"postcode", "street"}, schema.Required)
+	})
+
+	t.Run("should follow the constraints wrapped by other constraints", func(t *testing.T) {
+		schema, err := For[testAddress](validation.Sequence(
+			constraints.Template(validation.Fields{"Street": constraints.Required}, "bad street"),
+			constraints.Details(validation.Fields{"Postcode": constraints.MaxLength(8)}, "bad postcode"),
+		))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"street"}, schema.Required)
+		assert.Equal(t, 8, *schema.Properties["postcode"].MaxLength)
+	})
+
+	t.Run("should report constraints wrapped by other constraints without applying them", func(t *testing.T) {
+		var applied bool
+		custom := validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			applied = true
+			return nil
+		})
+
+		schema, err := For[testAddress](validation.Fields{
+			"Street": validation.Sequence(constraints.Required, custom),
+		})
+
+		var unsupportedErr *UnsupportedError
+		require.True(t, errors.As(err, &unsupportedErr))
+		require.Len(t, unsupportedErr.Unsupported, 1)
+		assert.Equal(t, "#/properties/street", unsupportedErr.Unsupported[0].Path)
+		assert.False(t, applied)
+
+		// The rules of the other constraints are still described.
+		assert.Equal(t, []string{"street"}, schema.Required)
+	})
+
+	t.Run("should describe constraints by the codes set on them", func(t *testing.T) {
+		_, err := For[testAddress](validation.Fields{
+			"Street": constraints.Code(constraints.Required, "custom"),
+		})
+
+		var unsupportedErr *UnsupportedError
+		require.True(t, errors.As(err, &unsupportedErr))
+		require.Len(t, unsupportedErr.Unsupported, 1)
+		assert.Equal(t, "#/properties/street (custom)", unsupportedErr.Unsupported[0].String())
+	})
+
+	t.Run("should report the element constraints of values with custom encodings", func(t *testing.T) {
+		_, err := For[testMarshalers](validation.Fields{
+			"Slice": validation.Elements{constraints.Required},
+			"Map":   validation.Elements{constraints.Required},
+		})
+
+		var unsupportedErr *UnsupportedError
+		require.True(t, errors.As(err, &unsupportedErr))
+		require.Len(t, unsupportedErr.Unsupported, 2)
+		assert.Equal(t, "#/properties/map", unsupportedErr.Unsupported[0].Path)
+		assert.Equal(t, "#/properties/slice", unsupportedErr.Unsupported[1].Path)
+	})
+
+	t.Run("should use the constraints of Validatable types if none are given", func(t *testing.T) {
+		schema, err := For[testPerson](nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"name"}, schema.Required)
+	})
+
+	t.Run("should not allow null for required pointers", func(t *testing.T) {
+		schema, err := For[*testAddress](validation.Constraints{constraints.Required})
+		require.NoError(t, err)
+
+		assert.Equal(t, "object", schema.Type)
+	})
+
+	t.Run("should report constraints that have no schema equivalent", func(t *testing.T) {
+		custom := validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			return nil
+		})
+
+		schema, err := For[testPerson](validation.Fields{
+			"Name":     validation.Constraints{constraints.Required, custom},
+			"Birthday": constraints.TimeAfter(time.Now()),
+			"Age":      constraints.AsWarning(constraints.Max(100)),
+			"Tags":     constraints.Regexp(regexp.MustCompile(`.`)),
+			"Secret":   constraints.Required,
+		})
+
+		var unsupportedErr *UnsupportedError
+		require.True(t, errors.As(err, &unsupportedErr))
+		require.Len(t, unsupportedErr.Unsupported, 5)
+
+		var unsupported []string
+		for _, u := range unsupportedErr.Unsupported {
+			unsupported = append(unsupported, u.String())
+		}
+
+		assert.Equal(t, []string{
+			"#/properties/age (validation.ConstraintFunc)",
+			"#/properties/birthday (time_after)",
+			"#/properties/name (validation.ConstraintFunc)",
+			"# (validation.ConstraintFunc)",
+			"#/properties/tags (regexp)",
+		}, unsupported)
+
+		// The rest of the schema is still generated.
+		assert.Equal(t, []string{"name"}, schema.Required)
+	})
+
+	t.Run("should report recursive constraints", func(t *testing.T) {
+		var node validation.Constraint
+		node = validation.Fields{
+			"Name": constraints.Required,
+			"Children": validation.Elements{validation.Lazy(func() validation.Constraint {
+				return node
+			})},
+		}
+
+		schema, err := For[testNode](node)
+
+		var unsupportedErr *UnsupportedError
+		require.True(t, errors.As(err, &unsupportedErr))
+		require.Len(t, unsupportedErr.Unsupported, 1)
+		assert.Equal(t, "#/properties/children/items", unsupportedErr.Unsupported[0].Path)
+
+		assert.Equal(t, "object", schema.Properties["children"].Items.Type)
+	})
+
+	t.Run("should panic if a field does not exist", func(t *testing.T) {
+		assert.Panics(t, func() {
+			_, _ = For[testAddress](validation.Fields{"Nope": constraints.Required})
+		})
+	})
+}
@@ -0,0 +1,315 @@
+// Package jsonschema generates JSON Schema (draft 2020-12) documents from constraints, so that
+// schemas published for a type can't drift from the constraints used to validate it.
+//
+// The schema of a type is based on how encoding/json encodes it, i.e. struct fields are described
+// by their JSON names, and any constraints applied to a value are added to its schema using their
+// JSON Schema equivalents, see Generate. Note that JSON Schema and this module don't always agree:
+//
+//   - Required requires a value to be non-empty, which is described as the property being present,
+//     and being non-empty (e.g. "minLength": 1 for strings). Constraints that check whether fields
+//     are set, like MutuallyExclusive, are described by the presence of properties alone.
+//   - Constraints other than Required allow empty values, which their schema equivalents don't. In
+//     JSON, empty values are usually omitted, so this rarely matters in practice.
+//   - MinLength, MaxLength, and Length count the bytes in strings, where JSON Schema counts
+//     characters, which only differs for strings that aren't ASCII.
+//   - Regexp patterns are Go regular expressions, and are used as-is. The syntax is compatible
+//     with the ECMA-262 syntax used by JSON Schema for most patterns.
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Dialect is the URI of the JSON Schema dialect of generated schemas.
+const Dialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema, or a subschema of one. Only the keywords used by Generate are included,
+// though more can be set on the schema (e.g. the title) before it's published.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Type is the name of the type of allowed values, or a []string of names if values may be of
+	// one of a number of types (e.g. "null", for pointers). If nil, values of any type are allowed.
+	Type            any    `json:"type,omitempty"`
+	Format          string `json:"format,omitempty"`
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	Const           any    `json:"const,omitempty"`
+	Enum            []any  `json:"enum,omitempty"`
+
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+	MaxItems *int    `json:"maxItems,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+}
+
+// addAllOf requires values to also match the given schema.
+func (s *Schema) addAllOf(schema *Schema) {
+	s.AllOf = append(s.AllOf, schema)
+}
+
+// addAnyOf requires values to match any of the given schemas, as well as any schemas they're
+// already required to match any of.
+func (s *Schema) addAnyOf(schemas ...*Schema) {
+	if s.AnyOf == nil {
+		s.AnyOf = schemas
+		return
+	}
+
+	s.addAllOf(&Schema{AnyOf: schemas})
+}
+
+// addConst requires values to be equal to the given value.
+func (s *Schema) addConst(value any) {
+	if s.Const == nil {
+		s.Const = value
+		return
+	}
+
+	s.addAllOf(&Schema{Const: value})
+}
+
+// addNot requires values not to match the given schema, as well as any schema they're already
+// required not to match.
+func (s *Schema) addNot(schema *Schema) {
+	if s.Not == nil {
+		s.Not = schema
+		return
+	}
+
+	s.addAllOf(&Schema{Not: schema})
+}
+
+// addRequired requires the object property with the given name to be present.
+func (s *Schema) addRequired(name string) {
+	if !slices.Contains(s.Required, name) {
+		s.Required = append(s.Required, name)
+	}
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// typeSchema returns the schema of values of the given type, as they're encoded by encoding/json.
+// Schemas of struct types that are already being generated (i.e. recursive types) only describe
+// the type of the value, so that they don't recurse forever.
+func (g *generator) typeSchema(typ reflect.Type) *Schema {
+	if typ.Kind() == reflect.Pointer {
+		schema := g.typeSchema(typ.Elem())
+		if name, ok := schema.Type.(string); ok {
+			schema.Type = []string{name, "null"}
+		}
+
+		return schema
+	}
+
+	switch {
+	case typ == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case implements(typ, jsonMarshalerType):
+		// There's no telling what custom encodings look like.
+		return &Schema{}
+	case implements(typ, textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if isBytes(typ) {
+			return &Schema{Type: "string", ContentEncoding: "base64"}
+		}
+
+		return &Schema{Type: "array", Items: g.typeSchema(typ.Elem())}
+	case reflect.Array:
+		length := typ.Len()
+		return &Schema{Type: "array", Items: g.typeSchema(typ.Elem()), MinItems: &length, MaxItems: &length}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.typeSchema(typ.Elem())}
+	case reflect.Struct:
+		return g.structSchema(typ)
+	}
+
+	// Interfaces may hold values of any type.
+	return &Schema{}
+}
+
+// structSchema returns the schema of values of the given struct type, see typeSchema.
+func (g *generator) structSchema(typ reflect.Type) *Schema {
+	schema := &Schema{Type: "object"}
+	if slices.Contains(g.types, typ) {
+		return schema
+	}
+
+	g.types = append(g.types, typ)
+	defer func() {
+		g.types = g.types[:len(g.types)-1]
+	}()
+
+	for _, field := range jsonFields(typ) {
+		if schema.Properties == nil {
+			schema.Properties = make(map[string]*Schema)
+		}
+
+		schema.Properties[field.name] = g.fieldSchema(field)
+	}
+
+	return schema
+}
+
+// fieldSchema returns the schema of the given struct field.
+func (g *generator) fieldSchema(field jsonField) *Schema {
+	if field.quoted {
+		return &Schema{Type: "string"}
+	}
+
+	return g.typeSchema(field.field.Type)
+}
+
+// jsonField is a struct field that is encoded as a member of JSON objects.
+type jsonField struct {
+	field reflect.StructField
+	name  string
+	// quoted is true if the field's value is encoded within a JSON string (the ",string" option).
+	quoted bool
+}
+
+// jsonFields returns the fields of the given struct type that are encoded as members of JSON
+// objects, including promoted fields, in the order they're encoded in.
+func jsonFields(typ reflect.Type) []jsonField {
+	var fields []jsonField
+	for _, field := range reflect.VisibleFields(typ) {
+		if jf, ok := newJSONField(field); ok {
+			fields = append(fields, jf)
+		}
+	}
+
+	// Shallower fields take precedence over deeper ones, as they do in encoding/json.
+	slices.SortStableFunc(fields, func(a, b jsonField) int {
+		return len(a.field.Index) - len(b.field.Index)
+	})
+
+	var visible []jsonField
+	for _, field := range fields {
+		if !slices.ContainsFunc(visible, func(f jsonField) bool { return f.name == field.name }) {
+			visible = append(visible, field)
+		}
+	}
+
+	slices.SortFunc(visible, func(a, b jsonField) int {
+		return slices.Compare(a.field.Index, b.field.Index)
+	})
+
+	return visible
+}
+
+// lookupJSONField returns the field with the given Go name on the given struct type, and true, or
+// false if it's not encoded as a member of JSON objects. It panics if there's no such field.
+func lookupJSONField(typ reflect.Type, name string) (jsonField, bool) {
+	field, ok := typ.FieldByName(name)
+	if !ok {
+		panic("jsonschema: field '" + name + "' does not exist")
+	}
+
+	for _, jf := range jsonFields(typ) {
+		if slices.Equal(jf.field.Index, field.Index) {
+			return jf, true
+		}
+	}
+
+	return jsonField{}, false
+}
+
+// newJSONField returns the given struct field as a jsonField, and true, or false if it isn't
+// encoded as a member of JSON objects of its own (e.g. embedded structs, whose fields are).
+func newJSONField(field reflect.StructField) (jsonField, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return jsonField{}, false
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+
+	if field.Anonymous && name == "" {
+		typ := field.Type
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		if typ.Kind() == reflect.Struct {
+			return jsonField{}, false
+		}
+	}
+
+	if !field.IsExported() {
+		return jsonField{}, false
+	}
+
+	if name == "" {
+		name = field.Name
+	}
+
+	var quoted bool
+	if slices.Contains(strings.Split(opts, ","), "string") {
+		switch field.Type.Kind() {
+		case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			quoted = true
+		}
+	}
+
+	return jsonField{field: field, name: name, quoted: quoted}, true
+}
+
+// implements returns true if the given type, or a pointer to it, implements the given interface.
+func implements(typ, iface reflect.Type) bool {
+	return typ.Implements(iface) || reflect.PointerTo(typ).Implements(iface)
+}
+
+// customEncoding returns true if values of the given type aren't encoded by encoding/json as
+// values of their kind, e.g. a slice that implements json.Marshaler.
+func customEncoding(typ reflect.Type) bool {
+	return typ == timeType || implements(typ, jsonMarshalerType) || implements(typ, textMarshalerType)
+}
+
+// isBytes returns true if the given type is a byte slice, which encoding/json encodes as a base64
+// string.
+func isBytes(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 && !implements(typ.Elem(), jsonMarshalerType) && !implements(typ.Elem(), textMarshalerType)
+}
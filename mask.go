@@ -0,0 +1,163 @@
+package validation
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// FieldMaskWildcard can be used as a path element in a FieldMask to match any map key.
+const FieldMaskWildcard = "*"
+
+// FieldMask restricts validation to a set of paths, e.g. the fields sent by a client in a partial
+// update. Paths are dot-separated lists of output field names (see FieldName), and map keys, e.g.
+// "address.street", or "labels.*" (where "*" matches any map key). Including a path also includes
+// every value beneath it. Array and slice indexes may be omitted from paths, in which case the
+// rest of the path applies to every element (e.g. "items.name").
+//
+// When a FieldMask is set on a Context, Fields, OrderedFields, Struct, Elements, Keys, and Map only
+// descend into values within the mask. Constraints that check multiple fields of a struct, like
+// MutuallyExclusive, are only applied if any of their fields are within the mask (see
+// Context.AnyFieldInMask).
+type FieldMask struct {
+	all      bool
+	children map[string]*FieldMask
+}
+
+// NewFieldMask returns a new FieldMask including the given paths.
+func NewFieldMask(paths ...string) *FieldMask {
+	mask := &FieldMask{}
+	for _, path := range paths {
+		mask.add(strings.Split(path, "."))
+	}
+
+	return mask
+}
+
+// FieldMaskFromProto returns a new FieldMask including the paths in the given ProtoBuf FieldMask.
+func FieldMaskFromProto(fm *fieldmaskpb.FieldMask) *FieldMask {
+	return NewFieldMask(fm.GetPaths()...)
+}
+
+// WithFieldMask returns an Option that restricts validation to the given paths, see FieldMask.
+func WithFieldMask(paths ...string) Option {
+	return func(ctx *Context) {
+		ctx.Mask = NewFieldMask(paths...)
+	}
+}
+
+// WithProtoFieldMask returns an Option that restricts validation to the paths in the given ProtoBuf
+// FieldMask, see FieldMask.
+func WithProtoFieldMask(fm *fieldmaskpb.FieldMask) Option {
+	return func(ctx *Context) {
+		ctx.Mask = FieldMaskFromProto(fm)
+	}
+}
+
+// AnyFieldInMask returns true if any of the fields with the given (Go) names, on the struct value on
+// this Context, are within the FieldMask on this Context, or if there is no FieldMask. Constraints
+// that check multiple fields of a struct, like MutuallyExclusive, use this so that during partial
+// validation they're only applied if any of their fields are being validated.
+func (c *Context) AnyFieldInMask(fields ...string) bool {
+	if c.Mask == nil || c.Mask.all {
+		return true
+	}
+
+	for _, field := range fields {
+		if c.AnySegmentInMask(FieldSegment(field, FieldName(*c, field))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AnySegmentInMask is the more general form of AnyFieldInMask, returning true if any of the values
+// reached via the given segments from the value on this Context are within the FieldMask on this
+// Context, or if there is no FieldMask. This is for values that aren't structs, e.g. ProtoBuf
+// messages.
+func (c *Context) AnySegmentInMask(segments ...PathSegment) bool {
+	if c.Mask == nil || c.Mask.all {
+		return true
+	}
+
+	for _, segment := range segments {
+		if _, ok := c.Mask.child(segment); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// add includes the path made up of the given elements in this FieldMask.
+func (m *FieldMask) add(elements []string) {
+	if m.all {
+		return
+	}
+
+	if len(elements) == 0 || elements[0] == "" {
+		// This node is included in its entirety, so there's no need to keep its children.
+		m.all = true
+		m.children = nil
+		return
+	}
+
+	if m.children == nil {
+		m.children = make(map[string]*FieldMask)
+	}
+
+	child, ok := m.children[elements[0]]
+	if !ok {
+		child = &FieldMask{}
+		m.children[elements[0]] = child
+	}
+
+	child.add(elements[1:])
+}
+
+// child returns the FieldMask that applies to the value reached via the given segment, and true,
+// or false if the value isn't within this FieldMask. A nil FieldMask includes everything.
+func (m *FieldMask) child(segment PathSegment) (*FieldMask, bool) {
+	if m == nil || m.all {
+		return nil, true
+	}
+
+	var name string
+	switch segment.Kind {
+	case PathSegmentKindIndex:
+		// Indexes are optional in paths, if there isn't one, the mask applies to every element.
+		if child, ok := m.children[strconv.Itoa(segment.Index)]; ok {
+			return child.orNil(), true
+		}
+
+		return m, true
+	case PathSegmentKindKey:
+		name = segment.String()
+	default:
+		name = segment.Name
+	}
+
+	if child, ok := m.children[name]; ok {
+		return child.orNil(), true
+	}
+
+	if segment.Kind == PathSegmentKindKey {
+		if child, ok := m.children[FieldMaskWildcard]; ok {
+			return child.orNil(), true
+		}
+	}
+
+	return nil, false
+}
+
+// orNil returns nil if this FieldMask includes everything, so that checks can be skipped for the
+// values beneath it.
+func (m *FieldMask) orNil() *FieldMask {
+	if m.all {
+		return nil
+	}
+
+	return m
+}
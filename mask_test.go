@@ -0,0 +1,169 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type maskAddress struct {
+	Street string `validation:"street"`
+	City   string `validation:"city"`
+}
+
+type maskItem struct {
+	Name  string `validation:"name"`
+	Price int    `validation:"price"`
+}
+
+type maskTester struct {
+	Name    string            `validation:"name"`
+	Email   string            `validation:"email"`
+	Address *maskAddress      `validation:"address"`
+	Items   []maskItem        `validation:"items"`
+	Labels  map[string]string `validation:"labels"`
+}
+
+func maskValue() maskTester {
+	return maskTester{
+		Address: &maskAddress{},
+		Items:   []maskItem{{}, {}},
+		Labels:  map[string]string{"a": "", "b": ""},
+	}
+}
+
+func maskConstraints() validation.Constraint {
+	return validation.Fields{
+		"Name":  constraints.Required,
+		"Email": constraints.Required,
+		"Address": validation.Fields{
+			"Street": constraints.Required,
+			"City":   constraints.Required,
+		},
+		"Items": validation.Elements{validation.Fields{
+			"Name":  constraints.Required,
+			"Price": constraints.Required,
+		}},
+		"Labels": validation.Constraints{
+			validation.Keys{constraints.MinLength(2)},
+			validation.Elements{constraints.Required},
+		},
+	}
+}
+
+func TestWithFieldMask(t *testing.T) {
+	tt := []struct {
+		name     string
+		paths    []string
+		expected []string
+	}{
+		{
+			name:     "top-level fields",
+			paths:    []string{"name"},
+			expected: []string{".name"},
+		},
+		{
+			name:     "nested fields",
+			paths:    []string{"email", "address.city"},
+			expected: []string{".address.city", ".email"},
+		},
+		{
+			name:     "whole nested values",
+			paths:    []string{"address"},
+			expected: []string{".address.city", ".address.street"},
+		},
+		{
+			name:     "fields of every element",
+			paths:    []string{"items.price"},
+			expected: []string{".items.[0].price", ".items.[1].price"},
+		},
+		{
+			name:     "specific elements",
+			paths:    []string{"items.1.name"},
+			expected: []string{".items.[1].name"},
+		},
+		{
+			name:     "specific map keys",
+			paths:    []string{"labels.b"},
+			expected: []string{".labels.b", ".labels.b"},
+		},
+		{
+			name:  "any map key",
+			paths: []string{"labels.*"},
+			expected: []string{
+				".labels.a", ".labels.a", ".labels.b", ".labels.b",
+			},
+		},
+		{
+			name:     "nothing",
+			paths:    []string{"unknown"},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run("should only validate masked paths for "+tc.name, func(t *testing.T) {
+			ctx := validation.NewContext(maskValue(), validation.WithFieldMask(tc.paths...))
+
+			violations := validation.ValidateContext(ctx, maskConstraints())
+			assert.Equal(t, tc.expected, deepPaths(violations))
+		})
+	}
+
+	t.Run("should validate everything without a mask", func(t *testing.T) {
+		violations := validation.Validate(maskValue(), maskConstraints())
+		assert.Len(t, violations, 12)
+	})
+
+	t.Run("should apply to compiled, ordered, and struct constraints", func(t *testing.T) {
+		ctx := validation.NewContext(maskValue(), validation.WithFieldMask("email"))
+
+		violations := validation.ValidateContext(ctx,
+			validation.OrderedFields{validation.Field("Name", constraints.Required)},
+			validation.Struct(func(s *validation.StructRules[maskTester]) {
+				s.Field(func(m *maskTester) *string { return &m.Name }, constraints.Required)
+				s.Field(func(m *maskTester) *string { return &m.Email }, constraints.Required)
+			}),
+		)
+
+		assert.Equal(t, []string{".email"}, deepPaths(violations))
+	})
+
+	t.Run("should only apply struct-level constraints if any of their fields are masked", func(t *testing.T) {
+		value := maskTester{Name: "a", Email: "b"}
+		constraint := constraints.MutuallyExclusive("Name", "Email")
+
+		ctx := validation.NewContext(value, validation.WithFieldMask("address"))
+		assert.Empty(t, validation.ValidateContext(ctx, constraint))
+
+		ctx = validation.NewContext(value, validation.WithFieldMask("email"))
+		assert.Len(t, validation.ValidateContext(ctx, constraint), 1)
+	})
+}
+
+func TestWithProtoFieldMask(t *testing.T) {
+	t.Run("should only validate the paths in the field mask", func(t *testing.T) {
+		fm := &fieldmaskpb.FieldMask{Paths: []string{"name", "address.street"}}
+
+		ctx := validation.NewContext(maskValue(), validation.WithProtoFieldMask(fm))
+
+		violations := validation.ValidateContext(ctx, maskConstraints())
+		assert.Equal(t, []string{".address.street", ".name"}, deepPaths(violations))
+	})
+}
+
+func TestContext_AnyFieldInMask(t *testing.T) {
+	t.Run("should return true if there is no mask", func(t *testing.T) {
+		ctx := validation.NewContext(maskTester{})
+		assert.True(t, ctx.AnyFieldInMask("Name"))
+	})
+
+	t.Run("should return true if any of the fields are in the mask", func(t *testing.T) {
+		ctx := validation.NewContext(maskTester{}, validation.WithFieldMask("email"))
+		assert.True(t, ctx.AnyFieldInMask("Name", "Email"))
+		assert.False(t, ctx.AnyFieldInMask("Name", "Address"))
+	})
+}
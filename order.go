@@ -0,0 +1,182 @@
+package validation
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// OrderedFields is a Constraint used to validate the values of specific fields on a struct, exactly
+// like Fields, except that the fields are validated in the order given. This is mostly useful when
+// PreserveOrder is set on the Context, or when failing fast, to control which violations come first.
+type OrderedFields []OrderedField
+
+// OrderedField is a single field of OrderedFields, along with the constraints to apply to it.
+type OrderedField struct {
+	Name       string
+	Constraint Constraint
+}
+
+// Field returns an OrderedField for the field with the given name, for use with OrderedFields.
+func Field(name string, constraints ...Constraint) OrderedField {
+	return OrderedField{
+		Name:       name,
+		Constraint: Constraints(constraints),
+	}
+}
+
+// Violations ...
+func (of OrderedFields) Violations(ctx Context) []ConstraintViolation {
+	rval := UnwrapValue(ctx.Value().Node)
+	rtyp := UnwrapType(rval.Type())
+
+	if IsNillable(rval) && rval.IsNil() {
+		return nil
+	}
+
+	violations := ShouldBe(ctx, rtyp, reflect.Struct)
+	if len(violations) > 0 {
+		return violations
+	}
+
+	si := cachedStructInfo(rtyp)
+
+	fields := make([]*fieldInfo, 0, len(of))
+	for _, field := range of {
+		fi, ok := si.fields[field.Name]
+		if !ok {
+			panic(fmt.Sprintf("validation: field '%s' does not exist", field.Name))
+		}
+
+		fields = append(fields, fi)
+	}
+
+	violations = ctx.each(len(fields), func(ctx Context, i int) []ConstraintViolation {
+		fi := fields[i]
+
+		ctx = ctx.WithField(fi.field.Name, fi.name(ctx.StructTag), rval.FieldByIndex(fi.field.Index))
+		if ctx.outsideMask {
+			return nil
+		}
+
+		return ctx.apply(of[i].Constraint)
+	})
+
+	return ctx.limit(violations)
+}
+
+// WithPreserveOrder returns an Option that stops violations from being sorted by path, see
+// Context.PreserveOrder.
+func WithPreserveOrder() Option {
+	return func(ctx *Context) {
+		ctx.PreserveOrder = true
+	}
+}
+
+// sortViolations sorts the given violations by path, in place. Paths are compared naturally, so
+// that numbers (e.g. indexes) are compared by their numeric value, i.e. "[2]" comes before "[10]".
+// The sort is stable, so violations with the same path stay in the order they were produced in.
+func sortViolations(violations []ConstraintViolation) {
+	slices.SortStableFunc(violations, func(a, b ConstraintViolation) int {
+		return naturalCompare(a.Path, b.Path)
+	})
+}
+
+// naturalCompare compares the given strings, treating runs of digits as numbers. It returns -1 if
+// a comes before b, 1 if b comes before a, and 0 if they're equal.
+func naturalCompare(a, b string) int {
+	for len(a) > 0 && len(b) > 0 {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			aDigits, bDigits := digitRun(a), digitRun(b)
+
+			// Leading zeros don't change the value of a number, but we still need to compare the
+			// numbers in case the strings differ only by them.
+			aNumber, bNumber := trimZeros(aDigits), trimZeros(bDigits)
+			if c := cmp.Compare(len(aNumber), len(bNumber)); c != 0 {
+				return c
+			}
+
+			if c := cmp.Compare(aNumber, bNumber); c != 0 {
+				return c
+			}
+
+			if c := cmp.Compare(len(aDigits), len(bDigits)); c != 0 {
+				return c
+			}
+
+			a, b = a[len(aDigits):], b[len(bDigits):]
+			continue
+		}
+
+		if a[0] != b[0] {
+			return cmp.Compare(a[0], b[0])
+		}
+
+		a, b = a[1:], b[1:]
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+// isDigit returns true if the given byte is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// digitRun returns the run of digits at the start of the given string.
+func digitRun(s string) string {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+
+	return s[:i]
+}
+
+// trimZeros returns the given run of digits without any leading zeros.
+func trimZeros(digits string) string {
+	for len(digits) > 1 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+
+	return digits
+}
+
+// sortMapKeys sorts the given map keys in place, so that maps are always validated in the same
+// order. Numbers are compared by their value, and other keys are compared naturally, by their
+// string representation.
+func sortMapKeys(keys []reflect.Value) {
+	slices.SortStableFunc(keys, compareMapKeys)
+}
+
+// compareMapKeys compares the given map keys, see sortMapKeys.
+func compareMapKeys(a, b reflect.Value) int {
+	// Keys of interface-typed maps may hold values of different types, which are grouped by kind.
+	a, b = UnwrapValue(a), UnwrapValue(b)
+	if !a.IsValid() || !b.IsValid() || a.Kind() != b.Kind() {
+		return cmp.Compare(kindOf(a), kindOf(b))
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return naturalCompare(a.String(), b.String())
+	}
+
+	return naturalCompare(valueString(a), valueString(b))
+}
+
+// kindOf returns the kind of the given value, or reflect.Invalid if it's not valid.
+func kindOf(rval reflect.Value) reflect.Kind {
+	if !rval.IsValid() {
+		return reflect.Invalid
+	}
+
+	return rval.Kind()
+}
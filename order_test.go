@@ -0,0 +1,129 @@
+package validation_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderTester struct {
+	Zeta  string         `validation:"zeta"`
+	Alpha string         `validation:"alpha"`
+	Items []string       `validation:"items"`
+	Map   map[string]int `validation:"map"`
+}
+
+func messages(violations []validation.ConstraintViolation) []string {
+	var msgs []string
+	for _, violation := range violations {
+		msgs = append(msgs, violation.Message)
+	}
+
+	return msgs
+}
+
+func violationConstraint(message string) validation.Constraint {
+	return validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+		return []validation.ConstraintViolation{ctx.Violation(message, nil)}
+	})
+}
+
+func TestConstraints_Violations_Order(t *testing.T) {
+	t.Run("should sort indexes and keys by their numeric value", func(t *testing.T) {
+		value := orderTester{
+			Items: make([]string, 12),
+			Map:   map[string]int{"item10": 0, "item2": 0, "item1": 0},
+		}
+
+		violations := validation.Validate(value, validation.Fields{
+			"Items": validation.Elements{constraints.Required},
+			"Map":   validation.Elements{constraints.Required},
+		})
+
+		assert.Equal(t, []string{
+			".items.[0]", ".items.[1]", ".items.[2]", ".items.[3]", ".items.[4]", ".items.[5]",
+			".items.[6]", ".items.[7]", ".items.[8]", ".items.[9]", ".items.[10]", ".items.[11]",
+			".map.item1", ".map.item2", ".map.item10",
+		}, deepPaths(violations))
+	})
+
+	t.Run("should keep violations on the same path in the order they were produced", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			violations := validation.Validate(orderTester{}, violationConstraint("1"), violationConstraint("2"), violationConstraint("3"))
+			assert.Equal(t, []string{"1", "2", "3"}, messages(violations))
+		}
+	})
+
+	t.Run("should validate fields in declaration order", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			ctx := validation.NewContext(orderTester{})
+			ctx.FailFast = true
+
+			violations := validation.ValidateContext(ctx, validation.Fields{
+				"Alpha": constraints.Required,
+				"Zeta":  constraints.Required,
+			})
+
+			require.Len(t, violations, 1)
+			assert.Equal(t, ".zeta", violations[0].Path)
+		}
+	})
+
+	t.Run("should not sort violations if the order should be preserved", func(t *testing.T) {
+		ctx := validation.NewContext(orderTester{
+			Items: make([]string, 3),
+			Map:   map[string]int{"b": 0, "a": 0},
+		}, validation.WithPreserveOrder())
+
+		violations := validation.ValidateContext(ctx, validation.Fields{
+			"Alpha": constraints.Required,
+			"Items": validation.Elements{constraints.Required},
+			"Zeta":  constraints.Required,
+			"Map":   validation.Elements{constraints.Required},
+		}, violationConstraint("root"))
+
+		assert.Equal(t, []string{
+			".zeta", ".alpha", ".items.[0]", ".items.[1]", ".items.[2]", ".map.a", ".map.b", ".",
+		}, deepPaths(violations))
+	})
+}
+
+func TestOrderedFields(t *testing.T) {
+	constraint := validation.OrderedFields{
+		validation.Field("Alpha", constraints.Required),
+		validation.Field("Zeta", constraints.Required),
+		validation.Field("Alpha", violationConstraint("again")),
+	}
+
+	t.Run("should validate fields in the given order", func(t *testing.T) {
+		ctx := validation.NewContext(orderTester{}, validation.WithPreserveOrder())
+
+		violations := validation.ValidateContext(ctx, constraint)
+		assert.Equal(t, []string{".alpha", ".zeta", ".alpha"}, deepPaths(violations))
+	})
+
+	t.Run("should behave the same when compiled", func(t *testing.T) {
+		compiled := validation.Compile(reflect.TypeOf(orderTester{}), constraint)
+
+		ctx := validation.NewContext(orderTester{}, validation.WithPreserveOrder())
+
+		violations := validation.ValidateContext(ctx, compiled)
+		assert.Equal(t, []string{".alpha", ".zeta", ".alpha"}, deepPaths(violations))
+	})
+
+	t.Run("should panic if a field doesn't exist", func(t *testing.T) {
+		assert.Panics(t, func() {
+			validation.Validate(orderTester{}, validation.OrderedFields{validation.Field("Missing")})
+		})
+	})
+
+	t.Run("should return a violation if the value is not a struct", func(t *testing.T) {
+		violations := validation.Validate("hello", constraint)
+		require.Len(t, violations, 1)
+		assert.Equal(t, validation.CodeKind, violations[0].Code)
+	})
+}
@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// WithParallelism returns an Option that allows collections with at least threshold items to be
+// validated using up to the given number of workers, see Context.Workers.
+func WithParallelism(workers, threshold int) Option {
+	return func(ctx *Context) {
+		ctx.Workers = workers
+		ctx.ParallelThreshold = threshold
+	}
+}
+
+// parallel returns true if a collection with the given number of items should be validated in
+// parallel, according to this Context.
+func (c *Context) parallel(n int) bool {
+	return c.Workers > 1 && n > 1 && n >= c.ParallelThreshold
+}
+
+// each validates n items, calling fn with this Context and the index of each item (fn should
+// descend into the item itself). Items are validated in parallel if this Context allows it, but the
+// violations are always returned in item order, exactly as if the items had been validated
+// sequentially. The returned violations are not limited, see limit.
+func (c Context) each(n int, fn func(ctx Context, i int) []ConstraintViolation) []ConstraintViolation {
+	var violations []ConstraintViolation
+
+	if !c.parallel(n) {
+		for i := 0; i < n && !c.shouldStop(violations); i++ {
+			violations = append(violations, fn(c, i)...)
+		}
+
+		return violations
+	}
+
+	// Each worker descends into items using its own copy of this Context. Values is capped so that
+	// descending always copies it, instead of workers writing to the same backing array. Nested
+	// collections are validated sequentially by each worker, so that the number of goroutines
+	// stays bounded.
+	ctx := c
+	ctx.Values = c.Values[:len(c.Values):len(c.Values)]
+	ctx.Workers = 0
+
+	results := make([][]ConstraintViolation, n)
+
+	// When failing fast, items after the first one with an error-level violation don't need to be
+	// validated, as a sequential run would've stopped there.
+	var next atomic.Int64
+	var stopAt atomic.Int64
+	stopAt.Store(int64(n))
+
+	var recovered any
+	var recoverOnce sync.Once
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(c.Workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				// Panics are re-raised on the calling goroutine, where they can be recovered, as
+				// they would be in a sequential run.
+				if r := recover(); r != nil {
+					recoverOnce.Do(func() { recovered = r })
+					stopAt.Store(-1)
+				}
+			}()
+
+			for {
+				i := next.Add(1) - 1
+				if i >= int64(n) || i > stopAt.Load() || ctx.Err() != nil {
+					return
+				}
+
+				results[i] = fn(ctx, int(i))
+
+				if ctx.FailFast && HasErrors(results[i]) {
+					for current := stopAt.Load(); i < current; current = stopAt.Load() {
+						if stopAt.CompareAndSwap(current, i) {
+							break
+						}
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if recovered != nil {
+		panic(recovered)
+	}
+
+	for _, result := range results {
+		if c.shouldStop(violations) {
+			break
+		}
+
+		violations = append(violations, result...)
+	}
+
+	return violations
+}
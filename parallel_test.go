@@ -0,0 +1,142 @@
+package validation_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type parallelItem struct {
+	Name  string            `validation:"name"`
+	Count int               `validation:"count"`
+	Tags  map[string]string `validation:"tags"`
+}
+
+func parallelItems(n int) []parallelItem {
+	items := make([]parallelItem, n)
+	for i := range items {
+		items[i] = parallelItem{
+			Count: i % 7,
+			Tags:  map[string]string{"a": "", fmt.Sprint(i % 3): "b"},
+		}
+
+		if i%5 != 0 {
+			items[i].Name = fmt.Sprint("item ", i)
+		}
+	}
+
+	return items
+}
+
+func parallelConstraints() validation.Constraint {
+	return validation.Elements{
+		validation.Fields{
+			"Name":  constraints.Required,
+			"Count": constraints.Min(3),
+			"Tags": validation.Constraints{
+				validation.Keys{constraints.OneOf("a", "0", "1")},
+				validation.Elements{constraints.Required},
+				validation.Map{"a": constraints.MinLength(1)},
+			},
+		},
+	}
+}
+
+func TestWithParallelism(t *testing.T) {
+	t.Run("should set the number of workers and threshold", func(t *testing.T) {
+		ctx := validation.NewContext(1, validation.WithParallelism(4, 100))
+		assert.Equal(t, 4, ctx.Workers)
+		assert.Equal(t, 100, ctx.ParallelThreshold)
+	})
+}
+
+func TestValidateContext_Parallel(t *testing.T) {
+	items := parallelItems(1000)
+
+	tt := []struct {
+		name       string
+		value      any
+		constraint validation.Constraint
+	}{
+		{name: "Elements, Fields, Keys, and Map", value: items, constraint: parallelConstraints()},
+		{name: "compiled constraints", value: items, constraint: validation.Compile(reflect.TypeOf(items), parallelConstraints())},
+		{
+			name:  "Struct constraints",
+			value: items,
+			constraint: validation.Elements{validation.Struct(func(s *validation.StructRules[parallelItem]) {
+				s.Field(func(i *parallelItem) *string { return &i.Name }, constraints.Required)
+				s.Field(func(i *parallelItem) *int { return &i.Count }, constraints.Min(3))
+			})},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run("should return the same violations as a sequential run for "+tc.name, func(t *testing.T) {
+			expected := validation.Validate(tc.value, tc.constraint)
+			require.NotEmpty(t, expected)
+
+			for _, workers := range []int{2, 8, 64} {
+				ctx := validation.NewContext(tc.value, validation.WithParallelism(workers, 2))
+				assert.Equal(t, expected, validation.ValidateContext(ctx, tc.constraint))
+			}
+		})
+	}
+
+	t.Run("should return the same violations as a sequential run when failing fast", func(t *testing.T) {
+		// Compiled constraints are used so that the fields are always validated in the same order.
+		constraint := validation.Compile(reflect.TypeOf(items), parallelConstraints())
+
+		ctx := validation.NewContext(items)
+		ctx.FailFast = true
+
+		expected := validation.ValidateContext(ctx, constraint)
+		require.Len(t, expected, 1)
+
+		ctx.Workers = 8
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, expected, validation.ValidateContext(ctx, constraint))
+		}
+	})
+
+	t.Run("should not validate in parallel below the threshold", func(t *testing.T) {
+		var calls int
+		constraint := validation.Elements{validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			// This would be a data race if elements were validated in parallel.
+			calls++
+			return nil
+		})}
+
+		ctx := validation.NewContext(items, validation.WithParallelism(8, len(items)+1))
+		assert.Empty(t, validation.ValidateContext(ctx, constraint))
+		assert.Equal(t, len(items), calls)
+	})
+
+	t.Run("should panic on the calling goroutine if a constraint panics", func(t *testing.T) {
+		ctx := validation.NewContext(items, validation.WithParallelism(8, 2))
+		assert.Panics(t, func() {
+			validation.ValidateContext(ctx, validation.Elements{validation.Fields{"Missing": constraints.Required}})
+		})
+	})
+}
+
+func BenchmarkValidateParallel(b *testing.B) {
+	items := parallelItems(10000)
+	constraint := parallelConstraints()
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			validation.Validate(items, constraint)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			validation.ValidateContext(validation.NewContext(items, validation.WithParallelism(8, 100)), constraint)
+		}
+	})
+}
@@ -0,0 +1,365 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PathFormatter renders a path from the given segments. The PathFormatter set on a Context is used
+// to render the Path of every ConstraintViolation produced from that Context.
+type PathFormatter func(segments []PathSegment) string
+
+// All possible PathSegmentKind values.
+const (
+	PathSegmentKindField PathSegmentKind = iota
+	PathSegmentKindIndex
+	PathSegmentKindKey
+)
+
+// PathSegmentKind enumerates the different kinds of step that can be taken to reach a value from
+// its parent value, i.e. via a struct field, an array or slice index, or a map key.
+type PathSegmentKind int
+
+// MarshalJSON returns a JSON encoded version of the string representation of this
+// PathSegmentKind.
+func (k PathSegmentKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// UnmarshalJSON takes the given bytes and attempts to mutate this PathSegmentKind to the
+// appropriate value, if a valid value is given.
+func (k *PathSegmentKind) UnmarshalJSON(bs []byte) error {
+	if len(bs) < 2 || bs[0] != '"' || bs[len(bs)-1] != '"' {
+		return errors.New("expected PathSegmentKind value to start and end with double-quotes")
+	}
+
+	switch string(bs[1 : len(bs)-1]) {
+	case "field":
+		*k = PathSegmentKindField
+	case "index":
+		*k = PathSegmentKindIndex
+	case "key":
+		*k = PathSegmentKindKey
+	default:
+		return errors.New("invalid PathSegmentKind")
+	}
+
+	return nil
+}
+
+// String returns the string representation of this PathSegmentKind.
+func (k PathSegmentKind) String() string {
+	switch k {
+	case PathSegmentKindField:
+		return "field"
+	case PathSegmentKindIndex:
+		return "index"
+	case PathSegmentKindKey:
+		return "key"
+	default:
+		return "unknown"
+	}
+}
+
+// PathSegment is a single step in the path to a value being validated. Unlike a rendered path, a
+// slice of PathSegment retains exactly how each value was reached, so that violations can reliably
+// be mapped back onto the data that was validated.
+type PathSegment struct {
+	// Kind is the kind of step this segment represents.
+	Kind PathSegmentKind
+	// Name is the output name of a struct field (i.e. after any struct tags have been applied).
+	// Only set for field segments.
+	Name string
+	// Field is the Go name of a struct field. Only set for field segments, and may be empty if
+	// the segment wasn't created from a struct field (e.g. using Context.WithValue).
+	Field string
+	// Index is the index of an element in an array or slice. Only set for index segments.
+	Index int
+	// Key is the original, typed map key. Only set for key segments.
+	Key any
+}
+
+// FieldSegment returns a new PathSegment for a struct field, with the given Go field name, and
+// output name.
+func FieldSegment(field, name string) PathSegment {
+	return PathSegment{Kind: PathSegmentKindField, Name: name, Field: field}
+}
+
+// IndexSegment returns a new PathSegment for an element in an array or slice.
+func IndexSegment(index int) PathSegment {
+	return PathSegment{Kind: PathSegmentKindIndex, Index: index}
+}
+
+// KeySegment returns a new PathSegment for a map key.
+func KeySegment(key any) PathSegment {
+	return PathSegment{Kind: PathSegmentKindKey, Key: key}
+}
+
+// String returns the string representation of this PathSegment, as it appears in a rendered path.
+func (s PathSegment) String() string {
+	switch s.Kind {
+	case PathSegmentKindIndex:
+		return fmt.Sprintf("[%d]", s.Index)
+	case PathSegmentKindKey:
+		if s.Key == nil {
+			return "nil"
+		}
+		return valueString(reflect.ValueOf(s.Key))
+	default:
+		return s.Name
+	}
+}
+
+// pathSegmentJSON is the JSON representation of a PathSegment. Only the fields relevant to the
+// kind of segment are included.
+type pathSegmentJSON struct {
+	Kind  PathSegmentKind `json:"kind"`
+	Name  string          `json:"name,omitempty"`
+	Field string          `json:"field,omitempty"`
+	Index *int            `json:"index,omitempty"`
+	Key   any             `json:"key,omitempty"`
+}
+
+// MarshalJSON returns a JSON encoded version of this PathSegment, including only the information
+// that is relevant to its kind.
+func (s PathSegment) MarshalJSON() ([]byte, error) {
+	out := pathSegmentJSON{Kind: s.Kind}
+
+	switch s.Kind {
+	case PathSegmentKindField:
+		out.Name = s.Name
+		out.Field = s.Field
+	case PathSegmentKindIndex:
+		out.Index = &s.Index
+	case PathSegmentKindKey:
+		out.Key = s.Key
+		if _, err := json.Marshal(s.Key); err != nil {
+			// Not every map key can be encoded as JSON (e.g. struct keys), so we fall back to the
+			// string representation of the key instead.
+			out.Key = s.String()
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON takes the given bytes and attempts to mutate this PathSegment to match. Map keys
+// are decoded as whatever type encoding/json would decode them into given an any value.
+func (s *PathSegment) UnmarshalJSON(bs []byte) error {
+	var in pathSegmentJSON
+	if err := json.Unmarshal(bs, &in); err != nil {
+		return err
+	}
+
+	*s = PathSegment{
+		Kind:  in.Kind,
+		Name:  in.Name,
+		Field: in.Field,
+		Key:   in.Key,
+	}
+
+	if in.Index != nil {
+		s.Index = *in.Index
+	}
+
+	return nil
+}
+
+// FormatPath renders the given segments as a path, e.g. ".texts.[0]". The root of the path is
+// referred to as ".". This is the default PathFormatter.
+func FormatPath(segments []PathSegment) string {
+	pathBuilder := strings.Builder{}
+	pathBuilder.WriteString(".")
+
+	for i, segment := range segments {
+		name := segment.String()
+		if name != "" && i != 0 {
+			pathBuilder.WriteString(".")
+		}
+		pathBuilder.WriteString(name)
+	}
+
+	return pathBuilder.String()
+}
+
+// FormatJSONPointer renders the given segments as an RFC 6901 JSON Pointer, e.g. "/items/0/name".
+// The root of the path is referred to as "".
+func FormatJSONPointer(segments []PathSegment) string {
+	pathBuilder := strings.Builder{}
+
+	for _, segment := range segments {
+		name := segment.String()
+		if segment.Kind == PathSegmentKindIndex {
+			name = strconv.Itoa(segment.Index)
+		} else if segment.Kind == PathSegmentKindField && name == "" {
+			continue
+		}
+
+		pathBuilder.WriteString("/")
+		pathBuilder.WriteString(EscapeJSONPointer(name))
+	}
+
+	return pathBuilder.String()
+}
+
+// jsonPointerEscaper escapes reference tokens in JSON Pointers. The order matters here, "~" must
+// be escaped first so that escaped "/" characters aren't escaped again.
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// EscapeJSONPointer escapes the given reference token (e.g. a field name) for use in a JSON Pointer,
+// see FormatJSONPointer.
+func EscapeJSONPointer(token string) string {
+	return jsonPointerEscaper.Replace(token)
+}
+
+// FormatJSONPath renders the given segments as a JSONPath expression, e.g. "$.items[0].name". Names
+// that can't be written using dot notation are written using bracket notation instead, e.g.
+// "$.labels['app.kubernetes.io/name']". The root of the path is referred to as "$".
+func FormatJSONPath(segments []PathSegment) string {
+	pathBuilder := strings.Builder{}
+	pathBuilder.WriteString("$")
+
+	for _, segment := range segments {
+		name := segment.String()
+
+		switch {
+		case segment.Kind == PathSegmentKindIndex:
+			pathBuilder.WriteString(name)
+		case segment.Kind == PathSegmentKindField && name == "":
+			continue
+		case isIdentifier(name, false):
+			pathBuilder.WriteString(".")
+			pathBuilder.WriteString(name)
+		default:
+			pathBuilder.WriteString("['")
+			pathBuilder.WriteString(jsonPathEscaper.Replace(name))
+			pathBuilder.WriteString("']")
+		}
+	}
+
+	return pathBuilder.String()
+}
+
+// jsonPathEscaper escapes names in single-quoted JSONPath bracket notation.
+var jsonPathEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// FormatBracketPath renders the given segments using JavaScript-style property access, e.g.
+// "items[0].name". Names that aren't valid identifiers are written using bracket notation instead,
+// e.g. `labels["app.kubernetes.io/name"]`. The root of the path is referred to as "".
+func FormatBracketPath(segments []PathSegment) string {
+	pathBuilder := strings.Builder{}
+
+	for _, segment := range segments {
+		name := segment.String()
+
+		switch {
+		case segment.Kind == PathSegmentKindIndex:
+			pathBuilder.WriteString(name)
+		case segment.Kind == PathSegmentKindField && name == "":
+			continue
+		case isIdentifier(name, true):
+			if pathBuilder.Len() > 0 {
+				pathBuilder.WriteString(".")
+			}
+			pathBuilder.WriteString(name)
+		default:
+			// A JSON string is also a valid JavaScript string literal.
+			quoted, _ := json.Marshal(name)
+
+			pathBuilder.WriteString("[")
+			pathBuilder.Write(quoted)
+			pathBuilder.WriteString("]")
+		}
+	}
+
+	return pathBuilder.String()
+}
+
+// PrefixViolations returns a copy of the given violations, with the given segments added to the
+// start of their paths, e.g. when a service validates a value nested in another, and the violations
+// need to be returned relative to the outer value. Paths are re-rendered using the given formatter,
+// or FormatPath if it's nil. Violations without any segments (and with a path other than the root),
+// e.g. those from a google.rpc.BadRequest, have their path joined to the end of the rendered prefix
+// instead, as the formatter would join them.
+func PrefixViolations(violations []ConstraintViolation, formatter PathFormatter, prefix ...PathSegment) []ConstraintViolation {
+	if violations == nil {
+		return nil
+	}
+
+	if formatter == nil {
+		formatter = FormatPath
+	}
+
+	root := formatter(nil)
+
+	prefixed := make([]ConstraintViolation, 0, len(violations))
+	for _, violation := range violations {
+		switch {
+		case len(violation.Segments) > 0, violation.Path == root:
+			segments := make([]PathSegment, 0, len(prefix)+len(violation.Segments))
+			segments = append(segments, prefix...)
+			segments = append(segments, violation.Segments...)
+
+			violation.Segments = segments
+			violation.Path = formatter(segments)
+		default:
+			violation.Path = joinPath(formatter, prefix, violation.Path)
+		}
+
+		prefixed = append(prefixed, violation)
+	}
+
+	return prefixed
+}
+
+// joinPath joins the given rendered path to the end of the given prefix, rendered using the given
+// formatter. The path isn't parsed, so the separator is found by asking the formatter to render a
+// segment after the prefix, a field, or an index if the path starts with a bracket.
+func joinPath(formatter PathFormatter, prefix []PathSegment, path string) string {
+	prefixPath := formatter(prefix)
+
+	rest := strings.TrimPrefix(path, formatter(nil))
+	if len(prefix) == 0 || rest == "" {
+		return prefixPath + rest
+	}
+
+	probe, probePath := FieldSegment("_", "_"), "_"
+	if strings.HasPrefix(rest, "[") {
+		probe, probePath = IndexSegment(0), "[0]"
+	}
+
+	probed := strings.TrimPrefix(formatter(append(slices.Clip(prefix), probe)), prefixPath)
+
+	separator, ok := strings.CutSuffix(probed, probePath)
+	if !ok || strings.HasPrefix(rest, separator) {
+		return prefixPath + rest
+	}
+
+	return prefixPath + separator + rest
+}
+
+// isIdentifier returns true if the given name can be used with dot notation, i.e. it starts with a
+// letter or underscore, and contains only letters, digits, and underscores. JavaScript identifiers
+// may also contain "$".
+func isIdentifier(name string, allowDollar bool) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r == '$' && allowDollar:
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
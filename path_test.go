@@ -0,0 +1,329 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathSegmentKind_MarshalJSON(t *testing.T) {
+	t.Run("should return the PathSegmentKind as a JSON string", func(t *testing.T) {
+		for kind, expected := range map[validation.PathSegmentKind]string{
+			validation.PathSegmentKindField: `"field"`,
+			validation.PathSegmentKindIndex: `"index"`,
+			validation.PathSegmentKindKey:   `"key"`,
+		} {
+			bs, err := kind.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, expected, string(bs))
+		}
+	})
+}
+
+func TestPathSegmentKind_UnmarshalJSON(t *testing.T) {
+	t.Run("should set the PathSegmentKind from a JSON string", func(t *testing.T) {
+		var kind validation.PathSegmentKind
+
+		require.NoError(t, kind.UnmarshalJSON([]byte(`"key"`)))
+		assert.Equal(t, validation.PathSegmentKindKey, kind)
+		require.NoError(t, kind.UnmarshalJSON([]byte(`"index"`)))
+		assert.Equal(t, validation.PathSegmentKindIndex, kind)
+		require.NoError(t, kind.UnmarshalJSON([]byte(`"field"`)))
+		assert.Equal(t, validation.PathSegmentKindField, kind)
+	})
+
+	t.Run("should return an error if given an invalid value", func(t *testing.T) {
+		var kind validation.PathSegmentKind
+		assert.Error(t, kind.UnmarshalJSON([]byte(`"nope"`)))
+		assert.Error(t, kind.UnmarshalJSON([]byte(`123`)))
+	})
+}
+
+func TestPathSegment_String(t *testing.T) {
+	t.Run("should return the output name of field segments", func(t *testing.T) {
+		assert.Equal(t, "texts", validation.FieldSegment("Texts", "texts").String())
+	})
+
+	t.Run("should return the index of index segments in brackets", func(t *testing.T) {
+		assert.Equal(t, "[12]", validation.IndexSegment(12).String())
+	})
+
+	t.Run("should return the string representation of key segments", func(t *testing.T) {
+		assert.Equal(t, "hello", validation.KeySegment("hello").String())
+		assert.Equal(t, "123", validation.KeySegment(123).String())
+		assert.Equal(t, "nil", validation.KeySegment(nil).String())
+		assert.Equal(t, "nil", validation.KeySegment((*string)(nil)).String())
+	})
+}
+
+func TestPathSegment_MarshalJSON(t *testing.T) {
+	t.Run("should only include information relevant to the kind of segment", func(t *testing.T) {
+		segments := []validation.PathSegment{
+			validation.FieldSegment("Items", "items"),
+			validation.IndexSegment(0),
+			validation.KeySegment("name"),
+		}
+
+		bs, err := json.Marshal(segments)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"kind": "field", "name": "items", "field": "Items"},
+			{"kind": "index", "index": 0},
+			{"kind": "key", "key": "name"}
+		]`, string(bs))
+	})
+
+	t.Run("should fall back to the string representation of keys that can't be encoded", func(t *testing.T) {
+		type key struct{ A chan int }
+
+		bs, err := json.Marshal(validation.KeySegment(key{}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"kind": "key", "key": "{<nil>}"}`, string(bs))
+	})
+}
+
+func TestPathSegment_UnmarshalJSON(t *testing.T) {
+	t.Run("should decode segments encoded as JSON", func(t *testing.T) {
+		segments := []validation.PathSegment{
+			validation.FieldSegment("Items", "items"),
+			validation.IndexSegment(0),
+			validation.IndexSegment(3),
+			validation.KeySegment("name"),
+		}
+
+		bs, err := json.Marshal(segments)
+		require.NoError(t, err)
+
+		var decoded []validation.PathSegment
+		require.NoError(t, json.Unmarshal(bs, &decoded))
+		assert.Equal(t, segments, decoded)
+	})
+}
+
+func TestFormatPath(t *testing.T) {
+	t.Run("should render the root path as a single dot", func(t *testing.T) {
+		assert.Equal(t, ".", validation.FormatPath(nil))
+	})
+
+	t.Run("should join segments with dots", func(t *testing.T) {
+		path := validation.FormatPath([]validation.PathSegment{
+			validation.FieldSegment("Items", "items"),
+			validation.IndexSegment(0),
+			validation.FieldSegment("Name", "name"),
+		})
+
+		assert.Equal(t, ".items.[0].name", path)
+	})
+}
+
+func TestEscapeJSONPointer(t *testing.T) {
+	t.Run("should escape tildes and slashes", func(t *testing.T) {
+		assert.Equal(t, "a~1b~0c~01", validation.EscapeJSONPointer("a/b~c~1"))
+	})
+}
+
+func TestFormatJSONPointer(t *testing.T) {
+	t.Run("should render the root path as an empty string", func(t *testing.T) {
+		assert.Equal(t, "", validation.FormatJSONPointer(nil))
+	})
+
+	t.Run("should render segments as reference tokens", func(t *testing.T) {
+		path := validation.FormatJSONPointer([]validation.PathSegment{
+			validation.FieldSegment("Items", "items"),
+			validation.IndexSegment(0),
+			validation.FieldSegment("Name", "name"),
+		})
+
+		assert.Equal(t, "/items/0/name", path)
+	})
+
+	t.Run("should escape tildes and slashes in keys", func(t *testing.T) {
+		path := validation.FormatJSONPointer([]validation.PathSegment{
+			validation.FieldSegment("Labels", "labels"),
+			validation.KeySegment("a/b~c.d[0]"),
+		})
+
+		assert.Equal(t, "/labels/a~1b~0c.d[0]", path)
+	})
+
+	t.Run("should skip fields with no name", func(t *testing.T) {
+		path := validation.FormatJSONPointer([]validation.PathSegment{
+			validation.FieldSegment("", ""),
+			validation.IndexSegment(1),
+		})
+
+		assert.Equal(t, "/1", path)
+	})
+
+	t.Run("should render empty keys", func(t *testing.T) {
+		path := validation.FormatJSONPointer([]validation.PathSegment{
+			validation.FieldSegment("Labels", "labels"),
+			validation.KeySegment(""),
+		})
+
+		assert.Equal(t, "/labels/", path)
+	})
+}
+
+func TestFormatJSONPath(t *testing.T) {
+	t.Run("should render the root path as a dollar sign", func(t *testing.T) {
+		assert.Equal(t, "$", validation.FormatJSONPath(nil))
+	})
+
+	t.Run("should render segments using dot notation where possible", func(t *testing.T) {
+		path := validation.FormatJSONPath([]validation.PathSegment{
+			validation.FieldSegment("Items", "items"),
+			validation.IndexSegment(0),
+			validation.FieldSegment("Name", "name"),
+		})
+
+		assert.Equal(t, "$.items[0].name", path)
+	})
+
+	t.Run("should render names that aren't identifiers using bracket notation", func(t *testing.T) {
+		path := validation.FormatJSONPath([]validation.PathSegment{
+			validation.FieldSegment("Labels", "labels"),
+			validation.KeySegment("app.kubernetes.io/name"),
+			validation.KeySegment(`it's [a] \test`),
+			validation.KeySegment(12),
+		})
+
+		assert.Equal(t, `$.labels['app.kubernetes.io/name']['it\'s [a] \\test']['12']`, path)
+	})
+
+	t.Run("should render empty keys using bracket notation", func(t *testing.T) {
+		path := validation.FormatJSONPath([]validation.PathSegment{
+			validation.FieldSegment("Labels", "labels"),
+			validation.KeySegment(""),
+		})
+
+		assert.Equal(t, "$.labels['']", path)
+	})
+}
+
+func TestFormatBracketPath(t *testing.T) {
+	t.Run("should render the root path as an empty string", func(t *testing.T) {
+		assert.Equal(t, "", validation.FormatBracketPath(nil))
+	})
+
+	t.Run("should render segments using dot notation where possible", func(t *testing.T) {
+		path := validation.FormatBracketPath([]validation.PathSegment{
+			validation.FieldSegment("Items", "items"),
+			validation.IndexSegment(0),
+			validation.FieldSegment("Name", "name"),
+		})
+
+		assert.Equal(t, "items[0].name", path)
+	})
+
+	t.Run("should render names that aren't identifiers using bracket notation", func(t *testing.T) {
+		path := validation.FormatBracketPath([]validation.PathSegment{
+			validation.FieldSegment("Labels", "labels"),
+			validation.KeySegment(`app.kubernetes.io/"name"`),
+			validation.KeySegment("$ref"),
+			validation.KeySegment("[0]"),
+		})
+
+		assert.Equal(t, `labels["app.kubernetes.io/\"name\""].$ref["[0]"]`, path)
+	})
+
+	t.Run("should render empty keys using bracket notation", func(t *testing.T) {
+		path := validation.FormatBracketPath([]validation.PathSegment{
+			validation.FieldSegment("Labels", "labels"),
+			validation.KeySegment(""),
+		})
+
+		assert.Equal(t, `labels[""]`, path)
+	})
+
+	t.Run("should render paths starting with an index", func(t *testing.T) {
+		path := validation.FormatBracketPath([]validation.PathSegment{
+			validation.IndexSegment(2),
+			validation.FieldSegment("Name", "name"),
+		})
+
+		assert.Equal(t, "[2].name", path)
+	})
+}
+
+func TestPrefixViolations(t *testing.T) {
+	prefix := []validation.PathSegment{
+		validation.FieldSegment("Order", "order"),
+		validation.IndexSegment(1),
+	}
+
+	t.Run("should add the prefix to the segments and path of each violation", func(t *testing.T) {
+		violations := []validation.ConstraintViolation{
+			{Path: ".name", Segments: []validation.PathSegment{validation.FieldSegment("Name", "name")}},
+			{Path: "."},
+		}
+
+		prefixed := validation.PrefixViolations(violations, nil, prefix...)
+		require.Len(t, prefixed, 2)
+		assert.Equal(t, ".order.[1].name", prefixed[0].Path)
+		assert.Len(t, prefixed[0].Segments, 3)
+		assert.Equal(t, ".order.[1]", prefixed[1].Path)
+		assert.Len(t, prefixed[1].Segments, 2)
+
+		// The original violations are left as they were.
+		assert.Equal(t, ".name", violations[0].Path)
+		assert.Len(t, violations[0].Segments, 1)
+	})
+
+	t.Run("should render paths using the given formatter", func(t *testing.T) {
+		violations := []validation.ConstraintViolation{
+			{Path: "/name", Segments: []validation.PathSegment{validation.FieldSegment("Name", "name")}},
+		}
+
+		prefixed := validation.PrefixViolations(violations, validation.FormatJSONPointer, prefix...)
+		require.Len(t, prefixed, 1)
+		assert.Equal(t, "/order/1/name", prefixed[0].Path)
+	})
+
+	t.Run("should join the paths of violations without segments to the rendered prefix", func(t *testing.T) {
+		tt := []struct {
+			formatter validation.PathFormatter
+			paths     []string
+			expected  []string
+		}{
+			{
+				formatter: validation.FormatPath,
+				paths:     []string{".name", ".[0]", "items[0].name"},
+				expected:  []string{".order.[1].name", ".order.[1].[0]", ".order.[1].items[0].name"},
+			},
+			{
+				formatter: validation.FormatJSONPointer,
+				paths:     []string{"/name", "/0"},
+				expected:  []string{"/order/1/name", "/order/1/0"},
+			},
+			{
+				formatter: validation.FormatJSONPath,
+				paths:     []string{"$.name", "$[0]", "$['a.b']", "items[0].name"},
+				expected:  []string{"$.order[1].name", "$.order[1][0]", "$.order[1]['a.b']", "$.order[1].items[0].name"},
+			},
+			{
+				formatter: validation.FormatBracketPath,
+				paths:     []string{"name", "[0]", "items[0].name"},
+				expected:  []string{"order[1].name", "order[1][0]", "order[1].items[0].name"},
+			},
+		}
+
+		for _, tc := range tt {
+			var violations []validation.ConstraintViolation
+			for _, path := range tc.paths {
+				violations = append(violations, validation.ConstraintViolation{Path: path})
+			}
+
+			var paths []string
+			for _, violation := range validation.PrefixViolations(violations, tc.formatter, prefix...) {
+				assert.Empty(t, violation.Segments)
+				paths = append(paths, violation.Path)
+			}
+
+			assert.Equal(t, tc.expected, paths)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ProtoFields is a Constraint used to validate the values of specific fields on a ProtoBuf message,
+// like Fields does for structs. Fields are looked up using protoreflect, by their ProtoBuf name
+// (e.g. "foo_bar"), or their JSON name (e.g. "fooBar"), so the internal fields of generated structs
+// are never seen. Fields are validated in the order they're declared in on the message.
+//
+// Paths use the ProtoBuf names of fields, unless the StructTag on the Context is "json", in which
+// case their JSON names are used (see ProtoFieldName). The values of fields are given to their
+// constraints as plain Go values, so that other constraints work as they do with structs:
+//
+//   - Scalar fields are given as their Go type, and enums as their generated Go type.
+//   - Message fields are given as their proto.Message type, or as a nil pointer if they're not set.
+//   - Scalar fields with presence (i.e. optional fields, and fields in a oneof) are given as
+//     pointers, which are nil if the field isn't set. See constraints.ProtoRequired.
+//   - Repeated fields are given as a []any, and map fields as a map[any]any.
+//   - Timestamp and Duration messages are given as a *time.Time, and *time.Duration.
+type ProtoFields map[string]Constraint
+
+// Violations ...
+func (pf ProtoFields) Violations(ctx Context) []ConstraintViolation {
+	msg, violations := ProtoMessage(ctx)
+	if msg == nil {
+		return violations
+	}
+
+	md := msg.Descriptor()
+
+	fields := make([]protoreflect.FieldDescriptor, 0, len(pf))
+	constraints := make(map[protoreflect.FieldDescriptor]Constraint, len(pf))
+	for name, constraint := range pf {
+		fd := ProtoField(md, name)
+		if fd == nil {
+			panic(fmt.Sprintf("validation: field '%s' does not exist on message '%s'", name, md.FullName()))
+		}
+
+		fields = append(fields, fd)
+		constraints[fd] = constraint
+	}
+
+	// ProtoFields is a map, so we sort the fields to validate them in a consistent order.
+	slices.SortFunc(fields, func(a, b protoreflect.FieldDescriptor) int {
+		return a.Index() - b.Index()
+	})
+
+	violations = ctx.each(len(fields), func(ctx Context, i int) []ConstraintViolation {
+		fd := fields[i]
+
+		ctx = ctx.WithField(string(fd.Name()), ProtoFieldName(ctx, fd), protoFieldValue(msg, fd))
+		if ctx.outsideMask {
+			return nil
+		}
+
+		return ctx.apply(constraints[fd])
+	})
+
+	return ctx.limit(violations)
+}
+
+// ProtoMessage returns the ProtoBuf message that is the value on the given Context. If the value
+// isn't a ProtoBuf message, a violation is returned instead. If the value is a nil message, neither
+// a message nor any violations are returned.
+func ProtoMessage(ctx Context) (protoreflect.Message, []ConstraintViolation) {
+	rval := ctx.Value().Node
+	for rval.IsValid() && rval.Kind() == reflect.Interface {
+		rval = rval.Elem()
+	}
+
+	if !rval.IsValid() || (IsNillable(rval) && rval.IsNil()) {
+		return nil, nil
+	}
+
+	pm, ok := rval.Interface().(proto.Message)
+	if !ok {
+		return nil, []ConstraintViolation{
+			ctx.CodedViolation(CodeKind, "value should be a protobuf message", nil),
+		}
+	}
+
+	msg := pm.ProtoReflect()
+	if !msg.IsValid() {
+		return nil, nil
+	}
+
+	return msg, nil
+}
+
+// ProtoField returns the field with the given ProtoBuf name, or JSON name, on the given message
+// descriptor, or nil if there is no such field.
+func ProtoField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+
+	return md.Fields().ByJSONName(name)
+}
+
+// ProtoFieldName returns the output name of the given ProtoBuf field, i.e. its JSON name if the
+// StructTag on the given Context is "json", otherwise its ProtoBuf name.
+func ProtoFieldName(ctx Context, fd protoreflect.FieldDescriptor) string {
+	if ctx.StructTag == "json" {
+		return fd.JSONName()
+	}
+
+	return string(fd.Name())
+}
+
+// protoFieldValue returns the value of the given field on the given message, as described on
+// ProtoFields.
+func protoFieldValue(msg protoreflect.Message, fd protoreflect.FieldDescriptor) reflect.Value {
+	switch {
+	case fd.IsList():
+		list := msg.Get(fd).List()
+		if list.Len() == 0 {
+			return reflect.ValueOf([]any(nil))
+		}
+
+		values := make([]any, list.Len())
+		for i := range values {
+			values[i] = protoValue(fd, list.Get(i))
+		}
+
+		return reflect.ValueOf(values)
+	case fd.IsMap():
+		m := msg.Get(fd).Map()
+		if m.Len() == 0 {
+			return reflect.ValueOf(map[any]any(nil))
+		}
+
+		values := make(map[any]any, m.Len())
+		m.Range(func(key protoreflect.MapKey, val protoreflect.Value) bool {
+			values[key.Interface()] = protoValue(fd.MapValue(), val)
+			return true
+		})
+
+		return reflect.ValueOf(values)
+	}
+
+	// Unset fields still have a (default) value, which is used to find the type of the field.
+	val := reflect.ValueOf(protoValue(fd, msg.Get(fd)))
+	if !fd.HasPresence() {
+		return val
+	}
+
+	if fd.Message() != nil {
+		if !msg.Has(fd) {
+			return reflect.Zero(val.Type())
+		}
+
+		return val
+	}
+
+	// Scalars with presence are given as pointers, so that fields that are set to their zero value
+	// can be told apart from fields that aren't set at all.
+	ptr := reflect.New(val.Type())
+	if !msg.Has(fd) {
+		return reflect.Zero(ptr.Type())
+	}
+
+	ptr.Elem().Set(val)
+
+	return ptr
+}
+
+// protoValue returns the given value of the given field (or of an element of the given field) as a
+// plain Go value, as described on ProtoFields.
+func protoValue(fd protoreflect.FieldDescriptor, val protoreflect.Value) any {
+	switch {
+	case fd.Message() != nil:
+		switch m := val.Message().Interface().(type) {
+		case *timestamppb.Timestamp:
+			t := m.AsTime()
+			return &t
+		case *durationpb.Duration:
+			d := m.AsDuration()
+			return &d
+		default:
+			return m
+		}
+	case fd.Enum() != nil:
+		et, err := protoregistry.GlobalTypes.FindEnumByName(fd.Enum().FullName())
+		if err != nil {
+			// Without a registered Go type (e.g. with dynamic messages), the number is used.
+			return val.Enum()
+		}
+
+		return et.New(val.Enum())
+	}
+
+	return val.Interface()
+}
@@ -0,0 +1,244 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/seeruk/go-validation/validationpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// protoOrderFile describes a message with the kinds of fields that generated types in this repo
+// don't have (optional fields, oneofs, maps, and timestamps), for use with dynamicpb.
+const protoOrderFile = `
+name: "order.proto"
+package: "test"
+syntax: "proto3"
+dependency: "google/protobuf/timestamp.proto"
+message_type: {
+  name: "Order"
+  field: {name: "order_id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "orderId"}
+  field: {name: "quantity" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 json_name: "quantity" oneof_index: 1 proto3_optional: true}
+  field: {name: "labels" number: 3 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".test.Order.LabelsEntry" json_name: "labels"}
+  field: {name: "created_at" number: 4 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.Timestamp" json_name: "createdAt"}
+  field: {name: "card" number: 5 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "card" oneof_index: 0}
+  field: {name: "voucher" number: 6 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "voucher" oneof_index: 0}
+  nested_type: {
+    name: "LabelsEntry"
+    field: {name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "key"}
+    field: {name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "value"}
+    options: {map_entry: true}
+  }
+  oneof_decl: {name: "payment"}
+  oneof_decl: {name: "_quantity"}
+}
+`
+
+func newProtoOrder(t *testing.T) *dynamicpb.Message {
+	fdp := &descriptorpb.FileDescriptorProto{}
+	require.NoError(t, prototext.Unmarshal([]byte(protoOrderFile), fdp))
+
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	return dynamicpb.NewMessage(fd.Messages().ByName("Order"))
+}
+
+func setProtoField(msg *dynamicpb.Message, name string, val protoreflect.Value) {
+	msg.Set(msg.Descriptor().Fields().ByName(protoreflect.Name(name)), val)
+}
+
+func TestProtoFields(t *testing.T) {
+	t.Run("should validate fields using their proto names in paths", func(t *testing.T) {
+		violations := validation.Validate(&validationpb.ConstraintViolation{}, validation.ProtoFields{
+			"path":      constraints.Required,
+			"path_kind": constraints.Required,
+		})
+
+		assert.Equal(t, []string{".path", ".path_kind"}, deepPaths(violations))
+	})
+
+	t.Run("should use JSON names in paths if the struct tag is json", func(t *testing.T) {
+		validate := validation.CreateValidateFunc("json")
+		violations := validate(&validationpb.ConstraintViolation{}, validation.ProtoFields{
+			"path_kind": constraints.Required,
+		})
+
+		assert.Equal(t, []string{".pathKind"}, deepPaths(violations))
+	})
+
+	t.Run("should look fields up by their JSON names", func(t *testing.T) {
+		violations := validation.Validate(&validationpb.ConstraintViolation{}, validation.ProtoFields{
+			"pathKind": constraints.Required,
+		})
+
+		assert.Equal(t, []string{".path_kind"}, deepPaths(violations))
+	})
+
+	t.Run("should validate fields in declaration order", func(t *testing.T) {
+		ctx := validation.NewContext(&validationpb.ConstraintViolation{}, validation.WithPreserveOrder())
+		violations := validation.ValidateContext(ctx, validation.ProtoFields{
+			"group":    constraints.Required,
+			"code":     constraints.Required,
+			"message":  constraints.Required,
+			"template": constraints.Required,
+		})
+
+		assert.Equal(t, []string{".message", ".code", ".template", ".group"}, deepPaths(violations))
+	})
+
+	t.Run("should give enums as their generated Go type", func(t *testing.T) {
+		var severity any
+		validation.Validate(&validationpb.ConstraintViolation{Severity: validationpb.Severity_SEVERITY_WARNING}, validation.ProtoFields{
+			"severity": validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+				severity = ctx.Value().Node.Interface()
+				return nil
+			}),
+		})
+
+		assert.Equal(t, validationpb.Severity_SEVERITY_WARNING, severity)
+	})
+
+	t.Run("should give unset message fields as nil", func(t *testing.T) {
+		violations := validation.Validate(&validationpb.ConstraintViolation{}, validation.ProtoFields{
+			"details": validation.Constraints{constraints.Nil, validation.ProtoFields{
+				"fields": constraints.Required,
+			}},
+		})
+
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should validate nested messages", func(t *testing.T) {
+		violations := validation.Validate(&validationpb.ConstraintViolation{Details: &structpb.Struct{}}, validation.ProtoFields{
+			"details": validation.ProtoFields{
+				"fields": constraints.Required,
+			},
+		})
+
+		assert.Equal(t, []string{".details.fields"}, deepPaths(violations))
+	})
+
+	t.Run("should validate the elements of repeated fields", func(t *testing.T) {
+		violation := &validationpb.ConstraintViolation{
+			Segments: []*validationpb.PathSegment{{Name: "a"}, {}},
+		}
+
+		violations := validation.Validate(violation, validation.ProtoFields{
+			"segments": validation.Elements{validation.ProtoFields{
+				"name": constraints.Required,
+			}},
+		})
+
+		assert.Equal(t, []string{".segments.[1].name"}, deepPaths(violations))
+	})
+
+	t.Run("should validate the values of map fields", func(t *testing.T) {
+		msg := newProtoOrder(t)
+		labels := msg.Mutable(msg.Descriptor().Fields().ByName("labels")).Map()
+		labels.Set(protoreflect.ValueOfString("a").MapKey(), protoreflect.ValueOfString("x"))
+		labels.Set(protoreflect.ValueOfString("b").MapKey(), protoreflect.ValueOfString(""))
+
+		violations := validation.Validate(msg, validation.ProtoFields{
+			"labels": validation.Map{
+				"a": constraints.Required,
+				"b": constraints.Required,
+			},
+		})
+
+		assert.Equal(t, []string{".labels.b"}, deepPaths(violations))
+	})
+
+	t.Run("should give fields with presence as pointers", func(t *testing.T) {
+		msg := newProtoOrder(t)
+
+		violations := validation.Validate(msg, validation.ProtoFields{
+			"quantity": constraints.Nil,
+			"card":     constraints.Nil,
+		})
+		assert.Empty(t, violations)
+
+		setProtoField(msg, "quantity", protoreflect.ValueOfInt32(0))
+		setProtoField(msg, "card", protoreflect.ValueOfString(""))
+
+		violations = validation.Validate(msg, validation.ProtoFields{
+			"quantity": constraints.Nil,
+			"card":     constraints.Nil,
+		})
+		assert.Equal(t, []string{".card", ".quantity"}, deepPaths(violations))
+	})
+
+	t.Run("should give timestamps as times", func(t *testing.T) {
+		msg := newProtoOrder(t)
+		now := time.Now()
+
+		setProtoField(msg, "created_at", protoreflect.ValueOfMessage(timestamppb.New(now.Add(-time.Hour)).ProtoReflect()))
+
+		violations := validation.Validate(msg, validation.ProtoFields{
+			"created_at": constraints.TimeAfter(now),
+		})
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, constraints.CodeTimeAfter, violations[0].Code)
+	})
+
+	t.Run("should only validate fields within the field mask", func(t *testing.T) {
+		ctx := validation.NewContext(&validationpb.ConstraintViolation{}, validation.WithFieldMask("message"))
+		violations := validation.ValidateContext(ctx, validation.ProtoFields{
+			"path":    constraints.Required,
+			"message": constraints.Required,
+		})
+
+		assert.Equal(t, []string{".message"}, deepPaths(violations))
+	})
+
+	t.Run("should return no violations for nil messages", func(t *testing.T) {
+		var violation *validationpb.ConstraintViolation
+
+		violations := validation.Validate(violation, validation.ProtoFields{
+			"path": constraints.Required,
+		})
+
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should return a violation if the value is not a message", func(t *testing.T) {
+		violations := validation.Validate("test", validation.ProtoFields{
+			"path": constraints.Required,
+		})
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, validation.CodeKind, violations[0].Code)
+	})
+
+	t.Run("should panic if a field does not exist", func(t *testing.T) {
+		assert.Panics(t, func() {
+			validation.Validate(&validationpb.ConstraintViolation{}, validation.ProtoFields{
+				"nope": constraints.Required,
+			})
+		})
+	})
+}
+
+func TestProtoMessage(t *testing.T) {
+	t.Run("should return the message on the context", func(t *testing.T) {
+		violation := &validationpb.ConstraintViolation{}
+
+		msg, violations := validation.ProtoMessage(validation.NewContext(violation))
+		require.NotNil(t, msg)
+		assert.Empty(t, violations)
+		assert.True(t, proto.Equal(violation, msg.Interface()))
+	})
+}
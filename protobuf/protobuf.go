@@ -16,14 +16,15 @@ func MapToStruct(in map[string]any) *structpb.Struct {
 
 	fields := make(map[string]*structpb.Value, inLen)
 	for k, v := range in {
-		fields[k] = toValue(v)
+		fields[k] = ToValue(v)
 	}
 
 	return &structpb.Struct{Fields: fields}
 }
 
-// toValue converts a Go value to a ProtoBuf 'Value', if possible.
-func toValue(v any) *structpb.Value {
+// ToValue converts a Go value to a ProtoBuf 'Value', if possible. Values of types that have no
+// ProtoBuf equivalent are converted to their string representation.
+func ToValue(v any) *structpb.Value {
 	switch v := v.(type) {
 	case nil:
 		return &structpb.Value{
@@ -34,6 +34,20 @@ func TestMapToStruct(t *testing.T) {
 	})
 }
 
+func TestToValue(t *testing.T) {
+	t.Run("should convert supported types to the equivalent ProtoBuf value", func(t *testing.T) {
+		assert.Equal(t, structpb.NewNullValue(), ToValue(nil))
+		assert.Equal(t, structpb.NewStringValue("hello"), ToValue("hello"))
+		assert.Equal(t, structpb.NewNumberValue(123), ToValue(123))
+		assert.Equal(t, structpb.NewBoolValue(true), ToValue(true))
+	})
+
+	t.Run("should convert unsupported types to their string representation", func(t *testing.T) {
+		type key struct{ A, B int }
+		assert.Equal(t, structpb.NewStringValue("{1 2}"), ToValue(key{A: 1, B: 2}))
+	})
+}
+
 func assertMapOfSupportedTypes(t *testing.T, input map[string]any, output *structpb.Struct) {
 	// nil
 	assert.IsType(t, &structpb.Value_NullValue{}, output.Fields["nil"].Kind)
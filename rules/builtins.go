@@ -0,0 +1,195 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+)
+
+// builtins contains the rules that are registered on every new Parser.
+var builtins = map[string]RuleFunc{
+	"required": noParam(constraints.Required),
+	"empty":    noParam(constraints.Empty),
+	"nil":      noParam(constraints.Nil, nillableKinds...),
+	"notnil":   noParam(constraints.NotNil, nillableKinds...),
+	"min":      floatParam(constraints.Min, numericKinds...),
+	"max":      floatParam(constraints.Max, numericKinds...),
+	"len":      intParam(constraints.Length, lengthKinds...),
+	"minlen":   intParam(constraints.MinLength, lengthKinds...),
+	"maxlen":   intParam(constraints.MaxLength, lengthKinds...),
+	"eq":       valueParam(constraints.Equals),
+	"ne":       valueParam(constraints.NotEquals),
+	"oneof":    valuesParam(constraints.OneOf[any]),
+	"noneof":   valuesParam(constraints.NoneOf[any]),
+	"regexp":   regexpParam,
+}
+
+// Kinds of value that the built-in rules may be applied to.
+var (
+	nillableKinds = []reflect.Kind{reflect.Chan, reflect.Func, reflect.Map, reflect.Ptr, reflect.Slice}
+	numericKinds  = []reflect.Kind{
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+	}
+	lengthKinds = []reflect.Kind{reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String}
+)
+
+// noParam returns a RuleFunc for a constraint that takes no param.
+func noParam(constraint validation.Constraint, kinds ...reflect.Kind) RuleFunc {
+	return func(typ reflect.Type, param string) (validation.Constraint, error) {
+		if param != "" {
+			return nil, errors.New("expected no param")
+		}
+
+		// Nil checks apply to the type before it's unwrapped.
+		if err := checkKind(typ, typ, kinds); err != nil {
+			return nil, err
+		}
+
+		return constraint, nil
+	}
+}
+
+// floatParam returns a RuleFunc for a constraint that takes a single number as its param.
+func floatParam(fn func(float64) validation.ConstraintFunc, kinds ...reflect.Kind) RuleFunc {
+	return func(typ reflect.Type, param string) (validation.Constraint, error) {
+		if err := checkKind(typ, validation.UnwrapType(typ), kinds); err != nil {
+			return nil, err
+		}
+
+		f, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", param)
+		}
+
+		return fn(f), nil
+	}
+}
+
+// intParam returns a RuleFunc for a constraint that takes a single integer as its param.
+func intParam(fn func(int) validation.ConstraintFunc, kinds ...reflect.Kind) RuleFunc {
+	return func(typ reflect.Type, param string) (validation.Constraint, error) {
+		if err := checkKind(typ, validation.UnwrapType(typ), kinds); err != nil {
+			return nil, err
+		}
+
+		i, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", param)
+		}
+
+		return fn(i), nil
+	}
+}
+
+// valueParam returns a RuleFunc for a constraint that takes a single value of the type being
+// validated as its param.
+func valueParam(fn func(any) validation.ConstraintFunc) RuleFunc {
+	return func(typ reflect.Type, param string) (validation.Constraint, error) {
+		value, err := parseValue(typ, param)
+		if err != nil {
+			return nil, err
+		}
+
+		return fn(value), nil
+	}
+}
+
+// valuesParam returns a RuleFunc for a constraint that takes at least 2 space-separated values of
+// the type being validated as its param.
+func valuesParam(fn func(...any) validation.ConstraintFunc) RuleFunc {
+	return func(typ reflect.Type, param string) (validation.Constraint, error) {
+		params := strings.Fields(param)
+		if len(params) < 2 {
+			return nil, fmt.Errorf("expected at least 2 space-separated values, got %q", param)
+		}
+
+		values := make([]any, 0, len(params))
+		for _, p := range params {
+			value, err := parseValue(typ, p)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+		}
+
+		return fn(values...), nil
+	}
+}
+
+// regexpParam is a RuleFunc for the Regexp constraint, which takes a pattern as its param.
+func regexpParam(typ reflect.Type, param string) (validation.Constraint, error) {
+	if err := checkKind(typ, validation.UnwrapType(typ), []reflect.Kind{reflect.String}); err != nil {
+		return nil, err
+	}
+
+	pattern, err := regexp.Compile(param)
+	if err != nil {
+		return nil, err
+	}
+
+	return constraints.Regexp(pattern), nil
+}
+
+// checkKind returns an error if the given type is not one of the given kinds. Interface types are
+// always allowed, as their underlying type is only known at validation-time.
+func checkKind(typ, checkTyp reflect.Type, kinds []reflect.Kind) error {
+	if len(kinds) == 0 || checkTyp.Kind() == reflect.Interface {
+		return nil
+	}
+
+	for _, kind := range kinds {
+		if checkTyp.Kind() == kind {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("can't be used on type %s", typ)
+}
+
+// parseValue parses the given string as a value of the given type, after unwrapping it.
+func parseValue(typ reflect.Type, s string) (any, error) {
+	utyp := validation.UnwrapType(typ)
+	rval := reflect.New(utyp).Elem()
+
+	switch utyp.Kind() {
+	case reflect.String:
+		rval.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("expected a bool, got %q", s)
+		}
+		rval.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, utyp.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer that fits in %s, got %q", typ, s)
+		}
+		rval.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, utyp.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("expected an unsigned integer that fits in %s, got %q", typ, s)
+		}
+		rval.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, utyp.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", s)
+		}
+		rval.SetFloat(f)
+	default:
+		return nil, fmt.Errorf("can't be used on type %s", typ)
+	}
+
+	return rval.Interface(), nil
+}
@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type status string
+
+func TestBuiltins(t *testing.T) {
+	cases := []struct {
+		rule  string
+		typ   reflect.Type
+		value any
+		ok    bool
+	}{
+		{rule: "required", typ: reflect.TypeOf(""), value: "", ok: false},
+		{rule: "required", typ: reflect.TypeOf(""), value: "a", ok: true},
+		{rule: "empty", typ: reflect.TypeOf(""), value: "a", ok: false},
+		{rule: "nil", typ: reflect.TypeOf([]int{}), value: []int{1}, ok: false},
+		{rule: "notnil", typ: reflect.TypeOf([]int{}), value: []int(nil), ok: false},
+		{rule: "min=2", typ: reflect.TypeOf(0), value: 1, ok: false},
+		{rule: "min=2", typ: reflect.TypeOf(0.0), value: 2.5, ok: true},
+		{rule: "max=2", typ: reflect.TypeOf(uint8(0)), value: uint8(3), ok: false},
+		{rule: "len=2", typ: reflect.TypeOf(""), value: "abc", ok: false},
+		{rule: "minlen=2", typ: reflect.TypeOf([]int{}), value: []int{1}, ok: false},
+		{rule: "maxlen=2", typ: reflect.TypeOf(map[int]int{}), value: map[int]int{1: 1, 2: 2, 3: 3}, ok: false},
+		{rule: "eq=3", typ: reflect.TypeOf(int64(0)), value: int64(3), ok: true},
+		{rule: "eq=true", typ: reflect.TypeOf(false), value: true, ok: true},
+		{rule: "ne=3", typ: reflect.TypeOf(int64(0)), value: int64(3), ok: false},
+		{rule: "oneof=active inactive", typ: reflect.TypeOf(status("")), value: status("active"), ok: true},
+		{rule: "oneof=active inactive", typ: reflect.TypeOf(status("")), value: status("deleted"), ok: false},
+		{rule: "oneof=1 2", typ: reflect.TypeOf(new(uint)), value: new(uint(3)), ok: false},
+		{rule: "noneof=1.5 2.5", typ: reflect.TypeOf(float32(0)), value: float32(1.5), ok: false},
+		{rule: "regexp=^[a-z]+$", typ: reflect.TypeOf(""), value: "abc1", ok: false},
+	}
+
+	for _, tc := range cases {
+		t.Run("should build a working constraint for "+tc.rule+" on "+tc.typ.String(), func(t *testing.T) {
+			constraint, err := NewParser("").parseRules(tc.typ, splitRules(tc.rule), nil)
+			require.NoError(t, err)
+
+			violations := validation.Validate(tc.value, constraint)
+			assert.Equal(t, tc.ok, len(violations) == 0, violations)
+		})
+	}
+
+	invalid := []struct {
+		rule string
+		typ  reflect.Type
+	}{
+		{rule: "required=1", typ: reflect.TypeOf("")},
+		{rule: "nil", typ: reflect.TypeOf("")},
+		{rule: "min", typ: reflect.TypeOf(0)},
+		{rule: "min=abc", typ: reflect.TypeOf(0)},
+		{rule: "min=1", typ: reflect.TypeOf("")},
+		{rule: "minlen=1.5", typ: reflect.TypeOf("")},
+		{rule: "maxlen=1", typ: reflect.TypeOf(0)},
+		{rule: "eq=abc", typ: reflect.TypeOf(0)},
+		{rule: "eq=256", typ: reflect.TypeOf(uint8(0))},
+		{rule: "eq=-1", typ: reflect.TypeOf(uint(0))},
+		{rule: "eq=maybe", typ: reflect.TypeOf(false)},
+		{rule: "eq=1", typ: reflect.TypeOf(struct{}{})},
+		{rule: "oneof=a", typ: reflect.TypeOf("")},
+		{rule: "noneof=1 a", typ: reflect.TypeOf(0)},
+		{rule: "regexp=[", typ: reflect.TypeOf("")},
+		{rule: "regexp=a", typ: reflect.TypeOf(0)},
+	}
+
+	for _, tc := range invalid {
+		t.Run("should return an error for "+tc.rule+" on "+tc.typ.String(), func(t *testing.T) {
+			_, err := NewParser("").parseRules(tc.typ, splitRules(tc.rule), nil)
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("should allow rules on interface types", func(t *testing.T) {
+		_, err := NewParser("").parseRules(reflect.TypeOf((*any)(nil)).Elem(), []string{"min=1", "minlen=1"}, nil)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,352 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/seeruk/go-validation"
+)
+
+// DefaultTag is the struct tag that rules are read from by default.
+const DefaultTag = "rules"
+
+// ErrUnknownRule is returned (wrapped in a FieldError) when a tag refers to a rule that hasn't been
+// registered.
+var ErrUnknownRule = errors.New("unknown rule")
+
+// RuleFunc is a function used to build a Constraint for a named rule. The given type is the type of
+// the value the rule will be applied to (e.g. the element type after "dive"), and the param is the
+// text after "=" in the tag, or an empty string if there wasn't one. An error should be returned if
+// the rule doesn't support the type, or if the param is invalid.
+type RuleFunc func(typ reflect.Type, param string) (validation.Constraint, error)
+
+// FieldError is returned when the rules on a struct field can't be parsed.
+type FieldError struct {
+	Type  reflect.Type
+	Field string
+	Err   error
+}
+
+// Error returns a description of the problem, including the field that caused it.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("rules: invalid rules on field %s.%s: %v", e.Type, e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Parser builds constraints from rules declared in struct tags, e.g.
+//
+//	type User struct {
+//		Name  string   `rules:"required,minlen=3,maxlen=64"`
+//		Roles []string `rules:"required,dive,oneof=admin user"`
+//	}
+//
+// Rules are separated by commas, and are run in the order they're declared. A literal comma can be
+// used in a rule's param by escaping it as "\,". After "dive", any remaining rules are applied to
+// each element of an array, slice, or map instead. For maps, rules between "keys" and "endkeys",
+// immediately after "dive", are applied to the map's keys.
+//
+// Fields that are structs (or pointers to structs), and elements that are structs after "dive", are
+// validated using the rules declared on their own fields too. Fields tagged with "-" are ignored.
+type Parser struct {
+	tag   string
+	rules map[string]RuleFunc
+}
+
+// NewParser returns a new Parser that reads rules from the given struct tag, with all of the built-in
+// rules registered. If the tag is empty, DefaultTag is used.
+func NewParser(tag string) *Parser {
+	if tag == "" {
+		tag = DefaultTag
+	}
+
+	p := &Parser{
+		tag:   tag,
+		rules: make(map[string]RuleFunc, len(builtins)),
+	}
+
+	for name, fn := range builtins {
+		p.rules[name] = fn
+	}
+
+	return p
+}
+
+// Register registers a custom rule under the given name, replacing any existing rule with the same
+// name, including built-in rules. Register is not safe to call concurrently with Parse.
+func (p *Parser) Register(name string, fn RuleFunc) {
+	switch {
+	case name == "" || strings.ContainsAny(name, ",="):
+		panic(fmt.Sprintf("rules: invalid rule name %q", name))
+	case name == "dive" || name == "keys" || name == "endkeys":
+		panic(fmt.Sprintf("rules: rule name %q is reserved", name))
+	case fn == nil:
+		panic("rules: rule function must not be nil")
+	}
+
+	p.rules[name] = fn
+}
+
+// Parse returns a Constraint built from the rules declared on the fields of the given struct type,
+// or a pointer to one. The returned Constraint can be freely combined with other constraints, e.g.
+// with a hand-written validation.Fields using validation.Constraints. An error is returned if any
+// rules can't be parsed, in which case it will be a *FieldError.
+func (p *Parser) Parse(typ reflect.Type) (validation.Constraint, error) {
+	utyp := validation.UnwrapType(typ)
+	if utyp.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("rules: expected a struct type, got %s", typ)
+	}
+
+	constraint, err := p.parseStruct(utyp, make(map[reflect.Type]*parsedStruct))
+	if err != nil {
+		return nil, err
+	}
+
+	if constraint == nil {
+		return validation.Constraints{}, nil
+	}
+
+	return constraint, nil
+}
+
+// MustParse is like Parse, but panics if the rules can't be parsed.
+func (p *Parser) MustParse(typ reflect.Type) validation.Constraint {
+	constraint, err := p.Parse(typ)
+	if err != nil {
+		panic(err)
+	}
+
+	return constraint
+}
+
+// parsedStruct is the result of parsing the rules on a struct type.
+type parsedStruct struct {
+	constraint validation.Constraint
+	done       bool
+}
+
+// parseStruct builds a Fields constraint for the given struct type, returning nil if there's
+// nothing to validate on it. Types that are still being parsed are referred to lazily, so that
+// recursive types can be parsed.
+func (p *Parser) parseStruct(typ reflect.Type, seen map[reflect.Type]*parsedStruct) (validation.Constraint, error) {
+	if parsed, ok := seen[typ]; ok {
+		if parsed.done {
+			return parsed.constraint, nil
+		}
+
+		return validation.Lazy(func() validation.Constraint {
+			if parsed.constraint == nil {
+				return validation.Constraints{}
+			}
+
+			return parsed.constraint
+		}), nil
+	}
+
+	parsed := &parsedStruct{}
+	seen[typ] = parsed
+
+	fields := validation.Fields{}
+
+	for _, field := range reflect.VisibleFields(typ) {
+		tag, hasTag := field.Tag.Lookup(p.tag)
+		if tag == "-" || (field.Anonymous && !hasTag) {
+			// Fields of embedded structs are promoted, so they're handled individually.
+			continue
+		}
+
+		if !field.IsExported() {
+			if hasTag {
+				return nil, &FieldError{Type: typ, Field: field.Name, Err: errors.New("rules can't be used on unexported fields")}
+			}
+			continue
+		}
+
+		if visible, ok := typ.FieldByName(field.Name); !ok || !slices.Equal(visible.Index, field.Index) {
+			// The field is shadowed by, or ambiguous with, another field of the same name.
+			continue
+		}
+
+		constraints, err := p.parseRules(field.Type, splitRules(tag), seen)
+		if err != nil {
+			var fieldErr *FieldError
+			if errors.As(err, &fieldErr) {
+				return nil, err
+			}
+
+			return nil, &FieldError{Type: typ, Field: field.Name, Err: err}
+		}
+
+		if len(constraints) > 0 {
+			fields[field.Name] = constraints
+		}
+	}
+
+	parsed.done = true
+	parsed.constraint = nil
+
+	if len(fields) > 0 {
+		parsed.constraint = fields
+	}
+
+	return parsed.constraint, nil
+}
+
+// parseRules builds the constraints for the given rules, applied to a value of the given type.
+func (p *Parser) parseRules(typ reflect.Type, rules []string, seen map[reflect.Type]*parsedStruct) (validation.Constraints, error) {
+	var constraints validation.Constraints
+
+	for i, rule := range rules {
+		switch rule {
+		case "dive":
+			dived, err := p.parseDive(typ, rules[i+1:], seen)
+			if err != nil {
+				return nil, err
+			}
+
+			return append(constraints, dived...), nil
+		case "keys", "endkeys":
+			return nil, fmt.Errorf("%q must immediately follow \"dive\" on a map", rule)
+		}
+
+		name, param, _ := strings.Cut(rule, "=")
+
+		fn, ok := p.rules[name]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownRule, name)
+		}
+
+		constraint, err := fn(typ, param)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", name, err)
+		}
+
+		constraints = append(constraints, constraint)
+	}
+
+	if validation.UnwrapType(typ).Kind() == reflect.Struct {
+		nested, err := p.parseStruct(validation.UnwrapType(typ), seen)
+		if err != nil {
+			return nil, err
+		}
+
+		if nested != nil {
+			constraints = append(constraints, nested)
+		}
+	}
+
+	return constraints, nil
+}
+
+// parseDive builds the constraints for the rules following "dive", applied to the keys and
+// elements of a value of the given type.
+func (p *Parser) parseDive(typ reflect.Type, rules []string, seen map[reflect.Type]*parsedStruct) (validation.Constraints, error) {
+	utyp := validation.UnwrapType(typ)
+
+	switch utyp.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice:
+	default:
+		return nil, fmt.Errorf("\"dive\" can't be used on type %s", typ)
+	}
+
+	var constraints validation.Constraints
+
+	if len(rules) > 0 && rules[0] == "keys" {
+		if utyp.Kind() != reflect.Map {
+			return nil, fmt.Errorf("\"keys\" can't be used on type %s", typ)
+		}
+
+		end := -1
+		for i, rule := range rules {
+			if rule == "endkeys" {
+				end = i
+				break
+			}
+		}
+
+		if end == -1 {
+			return nil, errors.New("\"keys\" must be followed by \"endkeys\"")
+		}
+
+		keyConstraints, err := p.parseRules(utyp.Key(), rules[1:end], seen)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(keyConstraints) > 0 {
+			constraints = append(constraints, validation.Keys(keyConstraints))
+		}
+
+		rules = rules[end+1:]
+	}
+
+	elemConstraints, err := p.parseRules(utyp.Elem(), rules, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(elemConstraints) > 0 {
+		constraints = append(constraints, validation.Elements(elemConstraints))
+	}
+
+	return constraints, nil
+}
+
+// splitRules splits the given tag into individual rules, separated by commas. Commas escaped with
+// a backslash are not treated as separators.
+func splitRules(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+
+	var rules []string
+
+	var rule strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			rule.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			rules = append(rules, rule.String())
+			rule.Reset()
+		default:
+			rule.WriteByte(tag[i])
+		}
+	}
+
+	return append(rules, rule.String())
+}
+
+// defaultParser is the Parser used by the package-level functions.
+var defaultParser = NewParser(DefaultTag)
+
+// Register registers a custom rule under the given name on the default Parser. It should be called
+// during program initialisation, as it's not safe to call concurrently with Parse.
+func Register(name string, fn RuleFunc) {
+	defaultParser.Register(name, fn)
+}
+
+// Parse returns a Constraint built from the rules declared on the fields of the given struct type
+// using the default Parser, reading rules from DefaultTag.
+func Parse(typ reflect.Type) (validation.Constraint, error) {
+	return defaultParser.Parse(typ)
+}
+
+// MustParse is like Parse, but panics if the rules can't be parsed.
+func MustParse(typ reflect.Type) validation.Constraint {
+	return defaultParser.MustParse(typ)
+}
+
+// For returns a Constraint built from the rules declared on the fields of T using the default
+// Parser. For panics if the rules can't be parsed, so is intended to be used when declaring
+// package-level variables, or in a type's Constraints method.
+func For[T any]() validation.Constraint {
+	return MustParse(reflect.TypeOf((*T)(nil)).Elem())
+}
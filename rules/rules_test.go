@@ -0,0 +1,277 @@
+package rules
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rulesTester struct {
+	embeddedTester
+
+	Name    string            `rules:"required,minlen=3,maxlen=8" json:"name"`
+	Age     *int              `rules:"min=18"`
+	Roles   []string          `rules:"required,dive,oneof=admin user"`
+	Labels  map[string]string `rules:"dive,keys,minlen=2,endkeys,required"`
+	Address *addressTester
+	Ignored string `rules:"-"`
+	NoRules string
+}
+
+type embeddedTester struct {
+	ID string `rules:"required"`
+}
+
+type addressTester struct {
+	Line1    string `rules:"required"`
+	Postcode string `rules:"regexp=^[A-Z]{1\\,2}[0-9]"`
+}
+
+type recursiveTester struct {
+	Name     string            `rules:"required"`
+	Children []recursiveTester `rules:"dive"`
+}
+
+func paths(violations []validation.ConstraintViolation) []string {
+	var paths []string
+	for _, violation := range violations {
+		paths = append(paths, violation.Path)
+	}
+
+	return paths
+}
+
+func TestParser_Parse(t *testing.T) {
+	t.Run("should return no violations for a valid value", func(t *testing.T) {
+		age := 21
+
+		value := rulesTester{
+			embeddedTester: embeddedTester{ID: "abc"},
+			Name:           "Elliot",
+			Age:            &age,
+			Roles:          []string{"admin"},
+			Labels:         map[string]string{"app": "test"},
+			Address:        &addressTester{Line1: "1 Test Street", Postcode: "AB1"},
+		}
+
+		violations := validation.Validate(value, NewParser("").MustParse(reflect.TypeOf(value)))
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should return violations for each field with broken rules", func(t *testing.T) {
+		age := 17
+
+		value := rulesTester{
+			Name:    "El",
+			Age:     &age,
+			Roles:   []string{"admin", "root"},
+			Labels:  map[string]string{"a": ""},
+			Address: &addressTester{Postcode: "1AB"},
+		}
+
+		violations := validation.Validate(value, NewParser("").MustParse(reflect.TypeOf(value)))
+		assert.Equal(t, []string{
+			".Address.Line1",
+			".Address.Postcode",
+			".Age",
+			".ID",
+			".Labels.a",
+			".Labels.a",
+			".Name",
+			".Roles.[1]",
+		}, paths(violations))
+	})
+
+	t.Run("should accept pointer types", func(t *testing.T) {
+		violations := validation.Validate(&embeddedTester{}, NewParser("").MustParse(reflect.TypeOf(&embeddedTester{})))
+		assert.Equal(t, []string{".ID"}, paths(violations))
+	})
+
+	t.Run("should read rules from the given tag", func(t *testing.T) {
+		type tester struct {
+			Name string `validate:"required"`
+		}
+
+		violations := validation.Validate(tester{}, NewParser("validate").MustParse(reflect.TypeOf(tester{})))
+		assert.Equal(t, []string{".Name"}, paths(violations))
+	})
+
+	t.Run("should support recursive types", func(t *testing.T) {
+		value := recursiveTester{
+			Name: "root",
+			Children: []recursiveTester{
+				{Name: "child", Children: []recursiveTester{{}}},
+			},
+		}
+
+		violations := validation.Validate(value, NewParser("").MustParse(reflect.TypeOf(value)))
+		assert.Equal(t, []string{".Children.[0].Children.[0].Name"}, paths(violations))
+	})
+
+	t.Run("should compose with hand-written constraints", func(t *testing.T) {
+		value := rulesTester{
+			embeddedTester: embeddedTester{ID: "abc"},
+			Name:           "Elliot",
+		}
+
+		violations := validation.Validate(value, validation.Constraints{
+			NewParser("").MustParse(reflect.TypeOf(value)),
+			validation.Fields{
+				"NoRules": constraints.Required,
+			},
+		})
+
+		assert.Equal(t, []string{".NoRules", ".Roles"}, paths(violations))
+	})
+
+	t.Run("should use custom rules", func(t *testing.T) {
+		type tester struct {
+			Name string `rules:"custom=hello"`
+		}
+
+		parser := NewParser("")
+		parser.Register("custom", func(typ reflect.Type, param string) (validation.Constraint, error) {
+			return constraints.Equals(param), nil
+		})
+
+		violations := validation.Validate(tester{Name: "world"}, parser.MustParse(reflect.TypeOf(tester{})))
+		require.Len(t, violations, 1)
+		assert.Equal(t, "hello", violations[0].Details["expected"])
+	})
+
+	t.Run("should return an error for unknown rules", func(t *testing.T) {
+		type tester struct {
+			Name string `rules:"required,unknown"`
+		}
+
+		_, err := NewParser("").Parse(reflect.TypeOf(tester{}))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrUnknownRule))
+
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "Name", fieldErr.Field)
+		assert.Equal(t, reflect.TypeOf(tester{}), fieldErr.Type)
+	})
+
+	t.Run("should return an error for the innermost field with invalid rules", func(t *testing.T) {
+		type inner struct {
+			Value int `rules:"min=abc"`
+		}
+
+		type outer struct {
+			Inner inner
+		}
+
+		_, err := NewParser("").Parse(reflect.TypeOf(outer{}))
+
+		var fieldErr *FieldError
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "Value", fieldErr.Field)
+		assert.Equal(t, reflect.TypeOf(inner{}), fieldErr.Type)
+	})
+
+	t.Run("should return an error if dive is used on a type that can't be dived into", func(t *testing.T) {
+		type tester struct {
+			Name string `rules:"dive,required"`
+		}
+
+		_, err := NewParser("").Parse(reflect.TypeOf(tester{}))
+		assert.Error(t, err)
+	})
+
+	t.Run("should return an error if keys are used incorrectly", func(t *testing.T) {
+		type keysOnSlice struct {
+			Names []string `rules:"dive,keys,required,endkeys"`
+		}
+
+		type keysWithoutEnd struct {
+			Labels map[string]string `rules:"dive,keys,required"`
+		}
+
+		type keysWithoutDive struct {
+			Labels map[string]string `rules:"keys,required,endkeys"`
+		}
+
+		for _, typ := range []reflect.Type{
+			reflect.TypeOf(keysOnSlice{}),
+			reflect.TypeOf(keysWithoutEnd{}),
+			reflect.TypeOf(keysWithoutDive{}),
+		} {
+			_, err := NewParser("").Parse(typ)
+			assert.Error(t, err, typ.String())
+		}
+	})
+
+	t.Run("should return an error for rules on unexported fields", func(t *testing.T) {
+		type tester struct {
+			name string `rules:"required"`
+		}
+
+		_, err := NewParser("").Parse(reflect.TypeOf(tester{}))
+		assert.Error(t, err)
+	})
+
+	t.Run("should return an error if not given a struct type", func(t *testing.T) {
+		_, err := NewParser("").Parse(reflect.TypeOf("hello"))
+		assert.Error(t, err)
+	})
+}
+
+func TestParser_MustParse(t *testing.T) {
+	t.Run("should panic if the rules can't be parsed", func(t *testing.T) {
+		type tester struct {
+			Name string `rules:"unknown"`
+		}
+
+		assert.Panics(t, func() {
+			NewParser("").MustParse(reflect.TypeOf(tester{}))
+		})
+	})
+}
+
+func TestParser_Register(t *testing.T) {
+	t.Run("should panic if given an invalid or reserved name", func(t *testing.T) {
+		fn := func(typ reflect.Type, param string) (validation.Constraint, error) {
+			return constraints.Required, nil
+		}
+
+		for _, name := range []string{"", "a,b", "a=b", "dive", "keys", "endkeys"} {
+			assert.Panics(t, func() {
+				NewParser("").Register(name, fn)
+			}, name)
+		}
+	})
+
+	t.Run("should panic if given a nil function", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewParser("").Register("custom", nil)
+		})
+	})
+}
+
+func TestFor(t *testing.T) {
+	t.Run("should return constraints for the given type", func(t *testing.T) {
+		violations := validation.Validate(embeddedTester{}, For[embeddedTester]())
+		assert.Equal(t, []string{".ID"}, paths(violations))
+	})
+}
+
+func TestSplitRules(t *testing.T) {
+	t.Run("should split rules on commas", func(t *testing.T) {
+		assert.Equal(t, []string{"required", "min=1"}, splitRules("required,min=1"))
+	})
+
+	t.Run("should not split on escaped commas", func(t *testing.T) {
+		assert.Equal(t, []string{"regexp=^a{1,2}$", "required"}, splitRules(`regexp=^a{1\,2}$,required`))
+	})
+
+	t.Run("should return no rules for an empty tag", func(t *testing.T) {
+		assert.Empty(t, splitRules(""))
+	})
+}
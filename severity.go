@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// All possible Severity values.
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+// Severity enumerates how serious a ConstraintViolation is. Only violations with SeverityError
+// (the default) mean a value is invalid. Warnings and info violations can be used to flag things
+// like deprecated fields, or suspicious-but-allowed values, without rejecting the value.
+type Severity int
+
+// MarshalJSON returns a JSON encoded version of the string representation of this Severity.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON takes the given bytes and attempts to mutate this Severity to the appropriate
+// value, if a valid value is given.
+func (s *Severity) UnmarshalJSON(bs []byte) error {
+	if len(bs) < 2 || bs[0] != '"' || bs[len(bs)-1] != '"' {
+		return errors.New("expected Severity value to start and end with double-quotes")
+	}
+
+	switch string(bs[1 : len(bs)-1]) {
+	case "error":
+		*s = SeverityError
+	case "warning":
+		*s = SeverityWarning
+	case "info":
+		*s = SeverityInfo
+	default:
+		return errors.New("invalid Severity")
+	}
+
+	return nil
+}
+
+// String returns the string representation of this Severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// HasErrors returns true if any of the given violations have SeverityError, i.e. if the value that
+// produced them is invalid.
+func HasErrors(violations []ConstraintViolation) bool {
+	for _, violation := range violations {
+		if violation.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SplitViolations splits the given violations into those that should block whatever is being
+// validated (i.e. those with SeverityError), and those that shouldn't (warnings, and info).
+func SplitViolations(violations []ConstraintViolation) (blocking, nonBlocking []ConstraintViolation) {
+	for _, violation := range violations {
+		if violation.Severity == SeverityError {
+			blocking = append(blocking, violation)
+		} else {
+			nonBlocking = append(nonBlocking, violation)
+		}
+	}
+
+	return blocking, nonBlocking
+}
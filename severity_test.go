@@ -0,0 +1,121 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverity_MarshalJSON(t *testing.T) {
+	t.Run("should return the Severity as a JSON string", func(t *testing.T) {
+		bs, err := json.Marshal([]validation.Severity{
+			validation.SeverityError,
+			validation.SeverityWarning,
+			validation.SeverityInfo,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, `["error","warning","info"]`, string(bs))
+	})
+}
+
+func TestSeverity_UnmarshalJSON(t *testing.T) {
+	t.Run("should set the Severity from a JSON string", func(t *testing.T) {
+		var severities []validation.Severity
+		require.NoError(t, json.Unmarshal([]byte(`["error","warning","info"]`), &severities))
+		assert.Equal(t, []validation.Severity{
+			validation.SeverityError,
+			validation.SeverityWarning,
+			validation.SeverityInfo,
+		}, severities)
+	})
+
+	t.Run("should return an error if given an invalid value", func(t *testing.T) {
+		var severity validation.Severity
+		assert.Error(t, json.Unmarshal([]byte(`"fatal"`), &severity))
+		assert.Error(t, json.Unmarshal([]byte(`1`), &severity))
+	})
+}
+
+func TestSeverity_String(t *testing.T) {
+	t.Run("should return a string representation of the Severity", func(t *testing.T) {
+		assert.Equal(t, "error", validation.SeverityError.String())
+		assert.Equal(t, "warning", validation.SeverityWarning.String())
+		assert.Equal(t, "info", validation.SeverityInfo.String())
+		assert.Equal(t, "unknown", validation.Severity(-1).String())
+	})
+}
+
+func TestHasErrors(t *testing.T) {
+	t.Run("should return true if any violations are errors", func(t *testing.T) {
+		assert.True(t, validation.HasErrors([]validation.ConstraintViolation{
+			{Severity: validation.SeverityWarning},
+			{Severity: validation.SeverityError},
+		}))
+	})
+
+	t.Run("should return false if no violations are errors", func(t *testing.T) {
+		assert.False(t, validation.HasErrors(nil))
+		assert.False(t, validation.HasErrors([]validation.ConstraintViolation{
+			{Severity: validation.SeverityWarning},
+			{Severity: validation.SeverityInfo},
+		}))
+	})
+}
+
+func TestSplitViolations(t *testing.T) {
+	t.Run("should split errors from warnings and info", func(t *testing.T) {
+		violations := []validation.ConstraintViolation{
+			{Path: ".a", Severity: validation.SeverityWarning},
+			{Path: ".b", Severity: validation.SeverityError},
+			{Path: ".c", Severity: validation.SeverityInfo},
+		}
+
+		blocking, nonBlocking := validation.SplitViolations(violations)
+		assert.Equal(t, []validation.ConstraintViolation{violations[1]}, blocking)
+		assert.Equal(t, []validation.ConstraintViolation{violations[0], violations[2]}, nonBlocking)
+	})
+}
+
+func TestContext_Severity(t *testing.T) {
+	type subject struct {
+		Name string `validation:"name"`
+		Age  int    `validation:"age"`
+	}
+
+	t.Run("should not fail fast on warnings", func(t *testing.T) {
+		ctx := validation.NewContext(subject{})
+		ctx.FailFast = true
+
+		violations := validation.ValidateContext(ctx, validation.Fields{
+			"Name": validation.Sequence(
+				constraints.AsWarning(constraints.Required),
+				constraints.Required,
+				constraints.MinLength(2),
+			),
+		})
+
+		require.Len(t, violations, 2)
+		assert.Equal(t, validation.SeverityWarning, violations[0].Severity)
+		assert.Equal(t, validation.SeverityError, violations[1].Severity)
+	})
+
+	t.Run("should not bail on warnings", func(t *testing.T) {
+		ctx := validation.NewContext(subject{})
+		ctx.BailPerPath = true
+
+		violations := validation.ValidateContext(ctx, validation.Fields{
+			"Age": validation.Constraints{
+				constraints.AsWarning(constraints.Required),
+				constraints.Required,
+				constraints.Min(18),
+			},
+		})
+
+		assert.Len(t, violations, 2)
+	})
+}
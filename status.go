@@ -0,0 +1,173 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorInfoReason is the reason set on the ErrorInfo detail attached to statuses by
+// ViolationsToStatus, see WithErrorInfo.
+const ErrorInfoReason = "VALIDATION_FAILED"
+
+// StatusOption is a function that configures the gRPC status made by ViolationsToStatus.
+type StatusOption func(*statusOptions)
+
+// statusOptions holds the configuration used by ViolationsToStatus.
+type statusOptions struct {
+	code                 codes.Code
+	summary              int
+	constraintViolations bool
+	badRequest           bool
+	errorInfo            bool
+	errorInfoDomain      string
+}
+
+// newStatusOptions returns the configuration made using the given option(s).
+func newStatusOptions(opts ...StatusOption) *statusOptions {
+	o := &statusOptions{
+		code:                 codes.InvalidArgument,
+		constraintViolations: true,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithStatusCode returns a StatusOption that sets the code of the status, e.g.
+// codes.FailedPrecondition. By default, codes.InvalidArgument is used.
+func WithStatusCode(code codes.Code) StatusOption {
+	return func(o *statusOptions) {
+		o.code = code
+	}
+}
+
+// WithStatusSummary returns a StatusOption that makes the message of the status list the paths and
+// messages of the first n violations, e.g. "validation failed: .name: a value is required". By
+// default, the message is just "validation failed".
+func WithStatusSummary(n int) StatusOption {
+	return func(o *statusOptions) {
+		o.summary = n
+	}
+}
+
+// WithBadRequest returns a StatusOption that attaches a google.rpc.BadRequest detail to the status,
+// with a FieldViolation for each error-level violation, so that tools that don't know about
+// validationpb can still read them. Fields are rendered using FormatBracketPath, e.g.
+// "items[0].name", and the reason of each FieldViolation is the code of its violation.
+func WithBadRequest() StatusOption {
+	return func(o *statusOptions) {
+		o.badRequest = true
+	}
+}
+
+// WithErrorInfo returns a StatusOption that attaches a google.rpc.ErrorInfo detail to the status,
+// with ErrorInfoReason as its reason, the given domain (e.g. "example.com"), and the codes of the
+// error-level violations as a comma-separated list in the "codes" metadata entry.
+func WithErrorInfo(domain string) StatusOption {
+	return func(o *statusOptions) {
+		o.errorInfo = true
+		o.errorInfoDomain = domain
+	}
+}
+
+// WithoutConstraintViolations returns a StatusOption that stops the validationpb
+// ConstraintViolations detail being attached to the status, e.g. when only a BadRequest detail is
+// wanted (see WithBadRequest).
+func WithoutConstraintViolations() StatusOption {
+	return func(o *statusOptions) {
+		o.constraintViolations = false
+	}
+}
+
+// details returns the status details to attach for the given violations.
+func (o *statusOptions) details(violations []ConstraintViolation) []protoadapt.MessageV1 {
+	var details []protoadapt.MessageV1
+	if o.constraintViolations {
+		details = append(details, ConstraintViolationsToProto(violations))
+	}
+
+	if o.badRequest {
+		details = append(details, violationsToBadRequest(violations))
+	}
+
+	if o.errorInfo {
+		details = append(details, violationsToErrorInfo(violations, o.errorInfoDomain))
+	}
+
+	return details
+}
+
+// message returns the message of the status for the given violations.
+func (o *statusOptions) message(violations []ConstraintViolation) string {
+	if o.summary < 1 {
+		return "validation failed"
+	}
+
+	return summarizeViolations(violations, o.summary)
+}
+
+// violationsToBadRequest returns a BadRequest detail holding the error-level violations in the given
+// violations, see WithBadRequest.
+func violationsToBadRequest(violations []ConstraintViolation) *errdetails.BadRequest {
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range violations {
+		if violation.Severity != SeverityError {
+			continue
+		}
+
+		field := violation.Path
+		if len(violation.Segments) > 0 {
+			field = FormatBracketPath(violation.Segments)
+		}
+
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: violation.Message,
+			Reason:      violation.Code,
+		})
+	}
+
+	return badRequest
+}
+
+// violationsFromBadRequest returns the field violations in the given BadRequest detail as
+// error-level violations.
+func violationsFromBadRequest(badRequest *errdetails.BadRequest) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0, len(badRequest.GetFieldViolations()))
+	for _, fieldViolation := range badRequest.GetFieldViolations() {
+		violations = append(violations, ConstraintViolation{
+			Path:     fieldViolation.GetField(),
+			PathKind: PathKindValue,
+			Severity: SeverityError,
+			Code:     fieldViolation.GetReason(),
+			Message:  fieldViolation.GetDescription(),
+		})
+	}
+
+	return violations
+}
+
+// violationsToErrorInfo returns an ErrorInfo detail for the given violations, see WithErrorInfo.
+func violationsToErrorInfo(violations []ConstraintViolation, domain string) *errdetails.ErrorInfo {
+	var violationCodes []string
+	for _, violation := range violations {
+		if violation.Severity == SeverityError && violation.Code != "" && !slices.Contains(violationCodes, violation.Code) {
+			violationCodes = append(violationCodes, violation.Code)
+		}
+	}
+
+	return &errdetails.ErrorInfo{
+		Reason: ErrorInfoReason,
+		Domain: domain,
+		Metadata: map[string]string{
+			"codes": strings.Join(violationCodes, ","),
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/validationpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func statusViolations() []validation.ConstraintViolation {
+	return []validation.ConstraintViolation{
+		{
+			Path: ".items.[0].name",
+			Segments: []validation.PathSegment{
+				validation.FieldSegment("Items", "items"),
+				validation.IndexSegment(0),
+				validation.FieldSegment("Name", "name"),
+			},
+			Code:    "required",
+			Message: "a value is required",
+		},
+		{Path: ".email", Code: "regexp", Message: "value must match regular expression '.+@.+'"},
+		{Path: ".nickname", Code: "required", Message: "a value is required"},
+		{Path: ".age", Severity: validation.SeverityWarning, Code: "min", Message: "value must be at least 18"},
+	}
+}
+
+func TestWithStatusCode(t *testing.T) {
+	t.Run("should set the code of the status", func(t *testing.T) {
+		sts := validation.ViolationsToStatus(statusViolations(), validation.WithStatusCode(codes.FailedPrecondition))
+		assert.Equal(t, codes.FailedPrecondition, sts.Code())
+		assert.Len(t, validation.ViolationsFromStatus(sts), 4)
+	})
+}
+
+func TestWithStatusSummary(t *testing.T) {
+	t.Run("should list the first n violations in the message", func(t *testing.T) {
+		sts := validation.ViolationsToStatus(statusViolations(), validation.WithStatusSummary(2))
+
+		expected := "validation failed: .items.[0].name: a value is required; " +
+			".email: value must match regular expression '.+@.+' (and 2 more)"
+		assert.Equal(t, expected, sts.Message())
+	})
+}
+
+func TestWithBadRequest(t *testing.T) {
+	t.Run("should attach a bad request detail alongside the constraint violations", func(t *testing.T) {
+		sts := validation.ViolationsToStatus(statusViolations(), validation.WithBadRequest())
+
+		details := sts.Details()
+		require.Len(t, details, 2)
+		assert.IsType(t, &validationpb.ConstraintViolations{}, details[0])
+
+		badRequest, ok := details[1].(*errdetails.BadRequest)
+		require.True(t, ok)
+
+		// Warnings are left out, as they're not bad requests.
+		fieldViolations := badRequest.GetFieldViolations()
+		require.Len(t, fieldViolations, 3)
+		assert.Equal(t, "items[0].name", fieldViolations[0].GetField())
+		assert.Equal(t, "a value is required", fieldViolations[0].GetDescription())
+		assert.Equal(t, "required", fieldViolations[0].GetReason())
+		assert.Equal(t, ".email", fieldViolations[1].GetField())
+	})
+
+	t.Run("should only attach a bad request detail without constraint violations", func(t *testing.T) {
+		sts := validation.ViolationsToStatus(statusViolations(), validation.WithBadRequest(), validation.WithoutConstraintViolations())
+
+		details := sts.Details()
+		require.Len(t, details, 1)
+		assert.IsType(t, &errdetails.BadRequest{}, details[0])
+	})
+}
+
+func TestWithErrorInfo(t *testing.T) {
+	t.Run("should attach an error info detail with the violation codes", func(t *testing.T) {
+		sts := validation.ViolationsToStatus(statusViolations(), validation.WithErrorInfo("example.com"))
+
+		details := sts.Details()
+		require.Len(t, details, 2)
+
+		errorInfo, ok := details[1].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		assert.Equal(t, validation.ErrorInfoReason, errorInfo.GetReason())
+		assert.Equal(t, "example.com", errorInfo.GetDomain())
+		assert.Equal(t, map[string]string{"codes": "required,regexp"}, errorInfo.GetMetadata())
+	})
+}
+
+func TestViolationsFromStatus_BadRequest(t *testing.T) {
+	t.Run("should return violations from bad request details", func(t *testing.T) {
+		sts, err := status.New(codes.InvalidArgument, "bad request").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "items[0].name", Description: "a value is required", Reason: "required"},
+			},
+		})
+		require.NoError(t, err)
+
+		violations := validation.ViolationsFromStatus(sts)
+		assert.Equal(t, []validation.ConstraintViolation{
+			{
+				Path:     "items[0].name",
+				PathKind: validation.PathKindValue,
+				Severity: validation.SeverityError,
+				Code:     "required",
+				Message:  "a value is required",
+			},
+		}, violations)
+	})
+
+	t.Run("should prefer constraint violations over bad request details", func(t *testing.T) {
+		sts := validation.ViolationsToStatus(statusViolations(), validation.WithBadRequest())
+		assert.Equal(t, statusViolations(), validation.ViolationsFromStatus(sts))
+	})
+}
@@ -0,0 +1,157 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Struct returns a Constraint used to validate the values of specific fields on a struct of type T,
+// much like Fields. Instead of referring to fields by name, fields are referred to using accessor
+// functions that return a pointer to the field, meaning renaming a field can't break validation:
+//
+//	validation.Struct(func(s *validation.StructRules[Person]) {
+//		s.Field(func(p *Person) *string { return &p.Name }, constraints.Required)
+//	})
+//
+// Accessor functions are called once, when Struct is called, so that the fields they refer to can
+// be resolved. Struct will panic if any accessor doesn't return a pointer to a field of T. The
+// violations produced, and their paths, are the same as those produced by Fields.
+func Struct[T any](fn func(s *StructRules[T])) Constraint {
+	rules := &StructRules[T]{
+		typ: reflect.TypeOf((*T)(nil)).Elem(),
+	}
+
+	MustBe(rules.typ, reflect.Struct)
+
+	fn(rules)
+
+	return &structConstraint{
+		typ:    rules.typ,
+		fields: rules.fields,
+	}
+}
+
+// StructRules is used to build up the constraints for the fields on a struct of type T. See Struct.
+type StructRules[T any] struct {
+	typ    reflect.Type
+	fields []structField
+}
+
+// Field adds the given constraints to the field of T that the given accessor function returns a
+// pointer to. The accessor must be a function that accepts a *T, and returns a pointer to one of
+// its fields, e.g. func(t *T) *string { return &t.Name }. Fields promoted from embedded structs are
+// also supported. Constraints are run in the order the fields were added.
+func (s *StructRules[T]) Field(accessor any, constraints ...Constraint) {
+	s.fields = append(s.fields, structField{
+		field:      resolveStructField(s.typ, accessor),
+		constraint: Constraints(constraints),
+	})
+}
+
+// structField is a field resolved by StructRules, along with the constraints to apply to it.
+type structField struct {
+	field      reflect.StructField
+	constraint Constraint
+}
+
+// structConstraint is the implementation of the Struct constraint.
+type structConstraint struct {
+	typ    reflect.Type
+	fields []structField
+}
+
+// Violations ...
+func (sc *structConstraint) Violations(ctx Context) []ConstraintViolation {
+	rval := UnwrapValue(ctx.Value().Node)
+	rtyp := UnwrapType(rval.Type())
+
+	if IsNillable(rval) && rval.IsNil() {
+		return nil
+	}
+
+	violations := ShouldBe(ctx, rtyp, reflect.Struct)
+	if len(violations) > 0 {
+		return violations
+	}
+
+	if rtyp != sc.typ {
+		panic(fmt.Sprintf("validation: Struct constraint for type %s used to validate type %s", sc.typ, rtyp))
+	}
+
+	violations = ctx.each(len(sc.fields), func(ctx Context, i int) []ConstraintViolation {
+		sf := sc.fields[i]
+		name := structFieldName(sf.field, ctx.StructTag)
+
+		ctx = ctx.WithField(sf.field.Name, name, rval.FieldByIndex(sf.field.Index))
+		if ctx.outsideMask {
+			return nil
+		}
+
+		return ctx.apply(sf.constraint)
+	})
+
+	return ctx.limit(violations)
+}
+
+// resolveStructField calls the given accessor function with a new value of the given struct type,
+// and finds the field that the returned pointer points to, by its offset in the struct.
+func resolveStructField(typ reflect.Type, accessor any) reflect.StructField {
+	rfn := reflect.ValueOf(accessor)
+	if rfn.Kind() != reflect.Func || rfn.IsNil() {
+		panic("validation: StructRules.Field expects an accessor function")
+	}
+
+	rfnt := rfn.Type()
+	if rfnt.NumIn() != 1 || rfnt.In(0) != reflect.PointerTo(typ) {
+		panic(fmt.Sprintf("validation: StructRules.Field expects an accessor function that accepts a single argument of type *%s", typ))
+	}
+
+	if rfnt.NumOut() != 1 || rfnt.Out(0).Kind() != reflect.Ptr {
+		panic("validation: StructRules.Field expects an accessor function that returns a pointer to a field")
+	}
+
+	base := reflect.New(typ)
+	ptr := rfn.Call([]reflect.Value{base})[0]
+	if ptr.IsNil() {
+		panic("validation: StructRules.Field accessor function returned nil")
+	}
+
+	start := base.Pointer()
+	addr := ptr.Pointer()
+	if addr < start || addr > start+typ.Size() {
+		panic(fmt.Sprintf("validation: StructRules.Field accessor function must return a pointer to a field of %s", typ))
+	}
+
+	field, ok := findStructField(typ, addr-start, ptr.Type().Elem())
+	if !ok {
+		panic(fmt.Sprintf("validation: StructRules.Field accessor function must return a pointer to a field of %s", typ))
+	}
+
+	return field
+}
+
+// findStructField finds the field in the given struct type at the given offset, with the given
+// type. Fields of embedded structs are searched too, as they're promoted to the outer struct.
+func findStructField(typ reflect.Type, offset uintptr, fieldType reflect.Type) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if offset == field.Offset && field.Type == fieldType {
+			return field, true
+		}
+
+		if !field.Anonymous || field.Type.Kind() != reflect.Struct {
+			continue
+		}
+
+		if offset < field.Offset || offset > field.Offset+field.Type.Size() {
+			continue
+		}
+
+		if embedded, ok := findStructField(field.Type, offset-field.Offset, fieldType); ok {
+			embedded.Index = append([]int{i}, embedded.Index...)
+			return embedded, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,175 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStruct(t *testing.T) {
+	type embeddedTester struct {
+		Qux string `validation:"qux"`
+	}
+
+	type structTester struct {
+		embeddedTester
+
+		Foo string `validation:"foo"`
+		Bar int
+		Baz []string `validation:"-"`
+	}
+
+	t.Run("should run all constraints", func(t *testing.T) {
+		testConstraint := &TestConstraint{}
+
+		Validate(structTester{}, Struct(func(s *StructRules[structTester]) {
+			s.Field(func(t *structTester) *string { return &t.Foo }, testConstraint)
+			s.Field(func(t *structTester) *int { return &t.Bar }, testConstraint)
+			s.Field(func(t *structTester) *[]string { return &t.Baz }, testConstraint)
+		}))
+
+		assert.Equal(t, 3, testConstraint.Calls)
+	})
+
+	t.Run("should return no violations if the given value is nil", func(t *testing.T) {
+		testConstraint := &TestConstraint{}
+
+		violations := Validate((*structTester)(nil), Struct(func(s *StructRules[structTester]) {
+			s.Field(func(t *structTester) *string { return &t.Foo }, testConstraint)
+		}))
+
+		assert.Len(t, violations, 0)
+		assert.Equal(t, 0, testConstraint.Calls)
+	})
+
+	t.Run("should update the context's value node to the fields of the given value", func(t *testing.T) {
+		value := structTester{
+			embeddedTester: embeddedTester{Qux: "embedded"},
+			Foo:            "this is a test",
+			Bar:            123,
+		}
+
+		var foo string
+		var bar int
+		var qux string
+
+		violations := Validate(value, Struct(func(s *StructRules[structTester]) {
+			s.Field(func(t *structTester) *string { return &t.Foo }, ConstraintFunc(func(ctx Context) []ConstraintViolation {
+				foo = ctx.Value().Node.Interface().(string)
+				return nil
+			}))
+			s.Field(func(t *structTester) *int { return &t.Bar }, ConstraintFunc(func(ctx Context) []ConstraintViolation {
+				bar = ctx.Value().Node.Interface().(int)
+				return nil
+			}))
+			s.Field(func(t *structTester) *string { return &t.Qux }, ConstraintFunc(func(ctx Context) []ConstraintViolation {
+				qux = ctx.Value().Node.Interface().(string)
+				return nil
+			}))
+		}))
+
+		require.Len(t, violations, 0)
+		assert.Equal(t, value.Foo, foo)
+		assert.Equal(t, value.Bar, bar)
+		assert.Equal(t, value.Qux, qux)
+	})
+
+	t.Run("should produce the same violations as Fields", func(t *testing.T) {
+		fieldsViolations := Validate(structTester{}, Fields{
+			"Foo": &TestConstraint{},
+			"Bar": &TestConstraint{},
+			"Baz": &TestConstraint{},
+			"Qux": &TestConstraint{},
+		})
+
+		structViolations := Validate(structTester{}, Struct(func(s *StructRules[structTester]) {
+			s.Field(func(t *structTester) *string { return &t.Foo }, &TestConstraint{})
+			s.Field(func(t *structTester) *int { return &t.Bar }, &TestConstraint{})
+			s.Field(func(t *structTester) *[]string { return &t.Baz }, &TestConstraint{})
+			s.Field(func(t *structTester) *string { return &t.Qux }, &TestConstraint{})
+		}))
+
+		assert.Equal(t, fieldsViolations, structViolations)
+	})
+
+	t.Run("should run multiple constraints on a field in order", func(t *testing.T) {
+		var calls []string
+
+		Validate(structTester{}, Struct(func(s *StructRules[structTester]) {
+			s.Field(func(t *structTester) *string { return &t.Foo },
+				ConstraintFunc(func(ctx Context) []ConstraintViolation {
+					calls = append(calls, "first")
+					return nil
+				}),
+				ConstraintFunc(func(ctx Context) []ConstraintViolation {
+					calls = append(calls, "second")
+					return nil
+				}),
+			)
+		}))
+
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("should return violations if the given type is not allowed, and the value is not empty", func(t *testing.T) {
+		violations := Validate("hello world", Struct(func(s *StructRules[structTester]) {
+			s.Field(func(t *structTester) *string { return &t.Foo }, &TestConstraint{})
+		}))
+
+		assert.Len(t, violations, 1)
+	})
+
+	t.Run("should panic if used to validate a different struct type", func(t *testing.T) {
+		constraint := Struct(func(s *StructRules[structTester]) {
+			s.Field(func(t *structTester) *string { return &t.Foo }, &TestConstraint{})
+		})
+
+		assert.Panics(t, func() {
+			Validate(TestSubject{}, constraint)
+		})
+	})
+
+	t.Run("should panic if the accessor doesn't return a pointer to a field", func(t *testing.T) {
+		assert.Panics(t, func() {
+			Struct(func(s *StructRules[structTester]) {
+				s.Field(func(t *structTester) *string { return new(string) })
+			})
+		})
+
+		assert.Panics(t, func() {
+			Struct(func(s *StructRules[structTester]) {
+				s.Field(func(t *structTester) *structTester { return t })
+			})
+		})
+
+		assert.Panics(t, func() {
+			Struct(func(s *StructRules[structTester]) {
+				s.Field(func(t *structTester) string { return t.Foo })
+			})
+		})
+
+		assert.Panics(t, func() {
+			Struct(func(s *StructRules[structTester]) {
+				s.Field(func(t *TestSubject) *string { return &t.Text })
+			})
+		})
+	})
+
+	t.Run("should panic if T is not a struct", func(t *testing.T) {
+		assert.Panics(t, func() {
+			Struct(func(s *StructRules[string]) {})
+		})
+	})
+
+	t.Run("should stop descending once cancelled", func(t *testing.T) {
+		ctx, testConstraint := cancellingContext(t, structTester{})
+
+		Struct(func(s *StructRules[structTester]) {
+			s.Field(func(t *structTester) *string { return &t.Foo }, testConstraint)
+			s.Field(func(t *structTester) *int { return &t.Bar }, testConstraint)
+		}).Violations(ctx)
+
+		assert.Equal(t, 1, testConstraint.Calls)
+	})
+}
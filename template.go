@@ -0,0 +1,154 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RenderTemplate renders the given message template, replacing placeholders like "{minimum}" with
+// the value of the matching param. Placeholders that don't match a param are left as they are, and
+// literal braces can be written as "{{" and "}}".
+//
+// Params are formatted in a human-readable way: numbers are never written in exponent form, times
+// are written in RFC 3339 format, and the elements of arrays and slices are separated by commas.
+func RenderTemplate(template string, params map[string]any) string {
+	if !strings.ContainsAny(template, "{}") {
+		return template
+	}
+
+	sb := strings.Builder{}
+	scanTemplate(template, func(text string) {
+		sb.WriteString(text)
+	}, func(name, placeholder string) {
+		if value, ok := params[name]; ok {
+			sb.WriteString(formatParam(reflect.ValueOf(value)))
+		} else {
+			sb.WriteString(placeholder)
+		}
+	})
+
+	return sb.String()
+}
+
+// TemplateParams returns the names of the params referenced by the placeholders in the given
+// message template, in the order they appear, see RenderTemplate.
+func TemplateParams(template string) []string {
+	var names []string
+	scanTemplate(template, func(string) {}, func(name, _ string) {
+		names = append(names, name)
+	})
+
+	return names
+}
+
+// scanTemplate splits the given message template into text, with any escaped braces unescaped,
+// and placeholders, calling the matching function for each in order, see RenderTemplate.
+func scanTemplate(template string, text func(text string), placeholder func(name, placeholder string)) {
+	for len(template) > 0 {
+		i := strings.IndexAny(template, "{}")
+		if i < 0 {
+			break
+		}
+
+		text(template[:i])
+
+		// Escaped braces are written out as single braces.
+		if i+1 < len(template) && template[i+1] == template[i] {
+			text(template[i : i+1])
+			template = template[i+2:]
+			continue
+		}
+
+		end := strings.IndexByte(template[i:], '}')
+		if template[i] == '}' || end < 0 {
+			text(template[i : i+1])
+			template = template[i+1:]
+			continue
+		}
+
+		end += i
+
+		placeholder(template[i+1:end], template[i:end+1])
+
+		template = template[end+1:]
+	}
+
+	text(template)
+}
+
+// TemplateViolation is exactly like CodedViolation, except the message is rendered from the given
+// template using the given details as params, see RenderTemplate. The template is kept on the
+// violation so that it can be rendered again later (e.g. by a client, after being translated).
+func (c *Context) TemplateViolation(code, template string, details map[string]any) ConstraintViolation {
+	violation := c.CodedViolation(code, RenderTemplate(template, details), details)
+	violation.Template = template
+
+	return violation
+}
+
+// formatParam returns the given param value formatted for use in a message, see RenderTemplate.
+func formatParam(rval reflect.Value) string {
+	for rval.Kind() == reflect.Ptr || rval.Kind() == reflect.Interface {
+		if rval.IsNil() {
+			return "null"
+		}
+
+		if rval.Type().Implements(stringerType) || rval.Type().Implements(errorType) {
+			break
+		}
+
+		rval = rval.Elem()
+	}
+
+	if !rval.IsValid() {
+		return "null"
+	}
+
+	switch value := rval.Interface().(type) {
+	case time.Time:
+		return value.Format(time.RFC3339)
+	case time.Duration:
+		return value.String()
+	case fmt.Stringer:
+		return value.String()
+	case error:
+		return value.Error()
+	}
+
+	switch rval.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rval.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rval.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rval.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(rval.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(rval.Float(), 'f', -1, 64)
+	case reflect.String:
+		return rval.String()
+	case reflect.Array, reflect.Slice:
+		if rval.Type().Elem().Kind() == reflect.Uint8 && rval.Kind() == reflect.Slice {
+			return string(rval.Bytes())
+		}
+
+		elements := make([]string, rval.Len())
+		for i := range elements {
+			elements[i] = formatParam(rval.Index(i))
+		}
+
+		return strings.Join(elements, ", ")
+	}
+
+	return fmt.Sprint(rval.Interface())
+}
+
+// These types are kept on their own here because they won't change.
+var (
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
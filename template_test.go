@@ -0,0 +1,71 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/seeruk/go-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	tt := []struct {
+		name     string
+		template string
+		params   map[string]any
+		expected string
+	}{
+		{name: "templates without placeholders", template: "a value is required", expected: "a value is required"},
+		{name: "strings", template: "hello {name}", params: map[string]any{"name": "world"}, expected: "hello world"},
+		{name: "integers", template: "{a} {b}", params: map[string]any{"a": -12, "b": uint8(3)}, expected: "-12 3"},
+		{name: "floats without exponents", template: "{a} {b}", params: map[string]any{"a": 1e6, "b": float32(0.5)}, expected: "1000000 0.5"},
+		{name: "booleans", template: "{a}", params: map[string]any{"a": true}, expected: "true"},
+		{name: "nil values", template: "{a} {b}", params: map[string]any{"a": nil, "b": (*int)(nil)}, expected: "null null"},
+		{name: "pointers", template: "{a}", params: map[string]any{"a": new(int)}, expected: "0"},
+		{
+			name:     "times",
+			template: "{a}",
+			params:   map[string]any{"a": time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)},
+			expected: "2024-02-03T04:05:06Z",
+		},
+		{name: "durations", template: "{a}", params: map[string]any{"a": 90 * time.Second}, expected: "1m30s"},
+		{name: "errors", template: "{a}", params: map[string]any{"a": errors.New("oops")}, expected: "oops"},
+		{name: "lists", template: "{a}", params: map[string]any{"a": []any{"x", 1, 2.5}}, expected: "x, 1, 2.5"},
+		{name: "arrays", template: "{a}", params: map[string]any{"a": [2]int{1, 2}}, expected: "1, 2"},
+		{name: "bytes", template: "{a}", params: map[string]any{"a": []byte("hi")}, expected: "hi"},
+		{name: "unknown placeholders", template: "{a} {b}", params: map[string]any{"a": 1}, expected: "1 {b}"},
+		{name: "escaped braces", template: "{{a}} {a} }}", params: map[string]any{"a": 1}, expected: "{a} 1 }"},
+		{name: "unclosed braces", template: "a { b } c {", params: map[string]any{" b ": 1}, expected: "a 1 c {"},
+	}
+
+	for _, tc := range tt {
+		t.Run("should render "+tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, validation.RenderTemplate(tc.template, tc.params))
+		})
+	}
+}
+
+func TestTemplateParams(t *testing.T) {
+	t.Run("should return the names of the placeholders in order", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "a"}, validation.TemplateParams("{a} {{c}} {b} {a} }} {"))
+	})
+
+	t.Run("should return nothing for templates without placeholders", func(t *testing.T) {
+		assert.Empty(t, validation.TemplateParams("a value is required"))
+	})
+}
+
+func TestContext_TemplateViolation(t *testing.T) {
+	t.Run("should render the message, and keep the template", func(t *testing.T) {
+		details := map[string]any{"minimum": 3}
+
+		ctx := validation.NewContext("")
+		violation := ctx.TemplateViolation("min_length", "must be at least {minimum} characters", details)
+
+		assert.Equal(t, "min_length", violation.Code)
+		assert.Equal(t, "must be at least 3 characters", violation.Message)
+		assert.Equal(t, "must be at least {minimum} characters", violation.Template)
+		assert.Equal(t, details, violation.Details)
+	})
+}
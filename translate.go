@@ -0,0 +1,52 @@
+package validation
+
+// Translator translates the messages of violations, e.g. into the locale of the request that is
+// being validated. Translations are usually looked up by the code of the violation. See the i18n
+// package for a catalog-based implementation.
+type Translator interface {
+	// Translate returns the translated message for the given violation, and true, or false if no
+	// translation is available for it.
+	Translate(violation ConstraintViolation) (string, bool)
+}
+
+// TranslatorFunc provides a convenient way of defining a Translator as a function.
+type TranslatorFunc func(violation ConstraintViolation) (string, bool)
+
+// Translate ...
+func (f TranslatorFunc) Translate(violation ConstraintViolation) (string, bool) {
+	return f(violation)
+}
+
+// WithTranslator returns an Option that sets the Translator used to translate the messages of any
+// violations, see Context.Translator.
+func WithTranslator(translator Translator) Option {
+	return func(ctx *Context) {
+		ctx.Translator = translator
+	}
+}
+
+// TranslateViolations returns a copy of the given violations, with their messages translated using
+// the given Translator. Violations that can't be translated keep their original message, as do those
+// with custom messages (see ConstraintViolation's Custom field). This can be used to translate
+// violations after validation, e.g. if they've been decoded from a response.
+func TranslateViolations(translator Translator, violations []ConstraintViolation) []ConstraintViolation {
+	if translator == nil || violations == nil {
+		return violations
+	}
+
+	translated := make([]ConstraintViolation, len(violations))
+	for i, violation := range violations {
+		if violation.Custom {
+			translated[i] = violation
+			continue
+		}
+
+		if message, ok := translator.Translate(violation); ok {
+			violation.Message = message
+		}
+
+		translated[i] = violation
+	}
+
+	return translated
+}
@@ -0,0 +1,69 @@
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var upperTranslator = validation.TranslatorFunc(func(violation validation.ConstraintViolation) (string, bool) {
+	if violation.Code == "" {
+		return "", false
+	}
+
+	return "[" + violation.Code + "]", true
+})
+
+func TestTranslateViolations(t *testing.T) {
+	t.Run("should return the violations as they are if there's no translator", func(t *testing.T) {
+		violations := testViolations()
+		assert.Equal(t, violations, validation.TranslateViolations(nil, violations))
+	})
+
+	t.Run("should translate messages, without modifying the given violations", func(t *testing.T) {
+		violations := []validation.ConstraintViolation{
+			{Path: ".a", Code: "required", Message: "a value is required"},
+			{Path: ".b", Message: "custom"},
+		}
+
+		translated := validation.TranslateViolations(upperTranslator, violations)
+		require.Len(t, translated, 2)
+		assert.Equal(t, "[required]", translated[0].Message)
+		assert.Equal(t, "custom", translated[1].Message)
+		assert.Equal(t, "a value is required", violations[0].Message)
+	})
+
+	t.Run("should not translate custom messages", func(t *testing.T) {
+		violations := []validation.ConstraintViolation{
+			{Path: ".a", Code: "min", Message: "you must be at least three", Custom: true},
+		}
+
+		translated := validation.TranslateViolations(upperTranslator, violations)
+		assert.Equal(t, "you must be at least three", translated[0].Message)
+	})
+}
+
+func TestValidateContext_Translator(t *testing.T) {
+	t.Run("should translate violations", func(t *testing.T) {
+		ctx := validation.NewContext("", validation.WithTranslator(upperTranslator))
+
+		violations := validation.ValidateContext(ctx, constraints.Required)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "[required]", violations[0].Message)
+	})
+
+	t.Run("should translate the cancellation violation", func(t *testing.T) {
+		stdCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		ctx := validation.NewContext("", validation.WithTranslator(upperTranslator)).WithContext(stdCtx)
+
+		violations := validation.ValidateContext(ctx, constraints.Required)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "[cancelled]", violations[0].Message)
+	})
+}
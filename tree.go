@@ -0,0 +1,260 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// Keys used in the JSON representation of a ViolationTree for the messages of violations that
+// can't be placed under a field, key, or index.
+const (
+	// TreeErrorsKey is the key holding the messages of violations of an object, or array itself,
+	// rather than any of its fields, keys, or elements.
+	TreeErrorsKey = "_errors"
+	// TreeKeyErrorsKey is the key holding the messages of violations of a map key, rather than the
+	// value under that key (i.e. violations with PathKindKey).
+	TreeKeyErrorsKey = "_key_errors"
+)
+
+// ViolationTree holds violations in a tree that mirrors the shape of the value that was validated,
+// with a node for each struct field, map key, and array or slice element that has violations. Each
+// node holds the violations of the value at that point in the tree.
+//
+// A ViolationTree is encoded as JSON in the shape of the validated value, with the messages of the
+// violations of each value at the leaves, e.g.:
+//
+//	{"address": {"street": ["a value is required"]}, "tags": [null, ["a value is required"]]}
+//
+// Arrays are padded with nulls, so that elements keep their indexes. Violations of values that
+// also have violations beneath them are encoded under TreeErrorsKey, and violations of map keys
+// are encoded under TreeKeyErrorsKey, making such values objects (including arrays).
+type ViolationTree struct {
+	// Segment is the segment of the path leading to this node from its parent. It's not set on
+	// the root node.
+	Segment PathSegment
+	// Violations are the violations of the value at this node.
+	Violations []ConstraintViolation
+	// KeyViolations are the violations of the map key leading to this node (i.e. violations with
+	// PathKindKey).
+	KeyViolations []ConstraintViolation
+	// Children are the nodes beneath this node, in the order they were first reached.
+	Children []*ViolationTree
+}
+
+// NewViolationTree returns a new ViolationTree holding the given violations, placed in the tree
+// using their Segments. Violations without any segments are placed on the root node.
+func NewViolationTree(violations []ConstraintViolation) *ViolationTree {
+	root := &ViolationTree{}
+
+	for _, violation := range violations {
+		node := root
+		for _, segment := range violation.Segments {
+			node = node.child(segment, true)
+		}
+
+		if violation.PathKind == PathKindKey {
+			node.KeyViolations = append(node.KeyViolations, violation)
+		} else {
+			node.Violations = append(node.Violations, violation)
+		}
+	}
+
+	return root
+}
+
+// Child returns the node beneath this node that is reached via the given segment, or nil if there
+// isn't one.
+func (t *ViolationTree) Child(segment PathSegment) *ViolationTree {
+	return t.child(segment, false)
+}
+
+// Find returns the node reached by following the given segments from this node, or nil if there
+// isn't one.
+func (t *ViolationTree) Find(segments ...PathSegment) *ViolationTree {
+	node := t
+	for _, segment := range segments {
+		if node = node.Child(segment); node == nil {
+			return nil
+		}
+	}
+
+	return node
+}
+
+// Flatten returns all of the violations in this tree as a flat list, i.e. the inverse of
+// NewViolationTree. The violations of each node come before those beneath it.
+func (t *ViolationTree) Flatten() []ConstraintViolation {
+	var violations []ConstraintViolation
+	t.flatten(&violations)
+
+	return violations
+}
+
+// MarshalJSON returns the JSON representation of this tree, as described on ViolationTree. An empty
+// tree is encoded as an empty object.
+func (t *ViolationTree) MarshalJSON() ([]byte, error) {
+	if len(t.Violations) == 0 && len(t.KeyViolations) == 0 && len(t.Children) == 0 {
+		return []byte("{}"), nil
+	}
+
+	buf := bytes.Buffer{}
+	if err := t.encode(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// child returns the node beneath this node that is reached via the given segment. If there isn't
+// one, and create is true, a new node is added and returned.
+func (t *ViolationTree) child(segment PathSegment, create bool) *ViolationTree {
+	for _, child := range t.Children {
+		if child.Segment.Kind == segment.Kind && child.Segment.String() == segment.String() {
+			return child
+		}
+	}
+
+	if !create {
+		return nil
+	}
+
+	child := &ViolationTree{Segment: segment}
+	t.Children = append(t.Children, child)
+
+	return child
+}
+
+// flatten appends the violations in this tree to the given violations.
+func (t *ViolationTree) flatten(violations *[]ConstraintViolation) {
+	*violations = append(*violations, t.KeyViolations...)
+	*violations = append(*violations, t.Violations...)
+
+	for _, child := range t.Children {
+		child.flatten(violations)
+	}
+}
+
+// encode writes the JSON representation of this node to the given buffer.
+func (t *ViolationTree) encode(buf *bytes.Buffer) error {
+	if len(t.Children) == 0 && len(t.KeyViolations) == 0 {
+		return encodeMessages(buf, t.Violations)
+	}
+
+	if len(t.Violations) == 0 && len(t.KeyViolations) == 0 && t.isArray() {
+		return t.encodeArray(buf)
+	}
+
+	buf.WriteByte('{')
+
+	first := true
+	writeKey := func(key string) {
+		if !first {
+			buf.WriteByte(',')
+		}
+
+		first = false
+
+		bs, _ := json.Marshal(key)
+		buf.Write(bs)
+		buf.WriteByte(':')
+	}
+
+	if len(t.Violations) > 0 {
+		writeKey(TreeErrorsKey)
+		if err := encodeMessages(buf, t.Violations); err != nil {
+			return err
+		}
+	}
+
+	if len(t.KeyViolations) > 0 {
+		writeKey(TreeKeyErrorsKey)
+		if err := encodeMessages(buf, t.KeyViolations); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range t.Children {
+		writeKey(child.key())
+		if err := child.encode(buf); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return nil
+}
+
+// encodeArray writes the JSON representation of this node as an array to the given buffer, with
+// each child at its index, and nulls in between.
+func (t *ViolationTree) encodeArray(buf *bytes.Buffer) error {
+	elements := make([]*ViolationTree, 0, len(t.Children))
+	for _, child := range t.Children {
+		for len(elements) <= child.Segment.Index {
+			elements = append(elements, nil)
+		}
+
+		elements[child.Segment.Index] = child
+	}
+
+	buf.WriteByte('[')
+
+	for i, element := range elements {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		if element == nil {
+			buf.WriteString("null")
+			continue
+		}
+
+		if err := element.encode(buf); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteByte(']')
+
+	return nil
+}
+
+// isArray returns true if all of the children of this node are array or slice elements.
+func (t *ViolationTree) isArray() bool {
+	for _, child := range t.Children {
+		if child.Segment.Kind != PathSegmentKindIndex || child.Segment.Index < 0 {
+			return false
+		}
+	}
+
+	return len(t.Children) > 0
+}
+
+// key returns the key of this node in the JSON representation of its parent, if the parent is
+// encoded as an object.
+func (t *ViolationTree) key() string {
+	switch t.Segment.Kind {
+	case PathSegmentKindIndex:
+		return strconv.Itoa(t.Segment.Index)
+	default:
+		return t.Segment.String()
+	}
+}
+
+// encodeMessages writes the messages of the given violations to the given buffer as a JSON array.
+func encodeMessages(buf *bytes.Buffer, violations []ConstraintViolation) error {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.Message)
+	}
+
+	bs, err := json.Marshal(messages)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(bs)
+
+	return nil
+}
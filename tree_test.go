@@ -0,0 +1,130 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/seeruk/go-validation"
+	"github.com/seeruk/go-validation/constraints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type treeAddress struct {
+	Street string `validation:"street"`
+}
+
+type treeTester struct {
+	Name    string            `validation:"name"`
+	Address treeAddress       `validation:"address"`
+	Tags    []string          `validation:"tags"`
+	Labels  map[string]string `validation:"labels"`
+}
+
+func treeViolations() []validation.ConstraintViolation {
+	value := treeTester{
+		Tags:   []string{"ok", "", "ok", ""},
+		Labels: map[string]string{"BAD": "x", "good": ""},
+	}
+
+	return validation.Validate(value, validation.Fields{
+		"Name":    constraints.Required,
+		"Address": validation.Fields{"Street": validation.Constraints{constraints.Required, constraints.MinLength(3)}},
+		"Tags": validation.Constraints{
+			validation.Elements{constraints.Required},
+		},
+		"Labels": validation.Constraints{
+			validation.Keys{constraints.Regexp(regexp.MustCompile(`^[a-z]+$`))},
+			validation.Elements{constraints.Required},
+		},
+	})
+}
+
+func TestNewViolationTree(t *testing.T) {
+	t.Run("should place violations in a tree mirroring the validated value", func(t *testing.T) {
+		tree := validation.NewViolationTree(treeViolations())
+
+		street := tree.Find(validation.FieldSegment("Address", "address"), validation.FieldSegment("Street", "street"))
+		require.NotNil(t, street)
+		assert.Len(t, street.Violations, 1)
+
+		tag := tree.Find(validation.FieldSegment("Tags", "tags"), validation.IndexSegment(3))
+		require.NotNil(t, tag)
+		assert.Len(t, tag.Violations, 1)
+
+		bad := tree.Find(validation.FieldSegment("Labels", "labels"), validation.KeySegment("BAD"))
+		require.NotNil(t, bad)
+		assert.Len(t, bad.KeyViolations, 1)
+		assert.Empty(t, bad.Violations)
+
+		assert.Nil(t, tree.Find(validation.FieldSegment("Tags", "tags"), validation.IndexSegment(0)))
+	})
+
+	t.Run("should place violations without segments on the root", func(t *testing.T) {
+		tree := validation.NewViolationTree([]validation.ConstraintViolation{{Path: ".", Message: "oops"}})
+		assert.Len(t, tree.Violations, 1)
+		assert.Empty(t, tree.Children)
+	})
+}
+
+func TestViolationTree_Flatten(t *testing.T) {
+	t.Run("should return the violations in the tree", func(t *testing.T) {
+		violations := treeViolations()
+
+		flattened := validation.NewViolationTree(violations).Flatten()
+		assert.ElementsMatch(t, violations, flattened)
+	})
+
+	t.Run("should return nil for an empty tree", func(t *testing.T) {
+		assert.Nil(t, validation.NewViolationTree(nil).Flatten())
+	})
+}
+
+func TestViolationTree_MarshalJSON(t *testing.T) {
+	t.Run("should encode the tree in the shape of the validated value", func(t *testing.T) {
+		bs, err := json.Marshal(validation.NewViolationTree(treeViolations()))
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"name": ["a value is required"],
+			"address": {"street": ["a value is required"]},
+			"tags": [null, ["a value is required"], null, ["a value is required"]],
+			"labels": {
+				"BAD": {"_key_errors": ["value must match regular expression"]},
+				"good": ["a value is required"]
+			}
+		}`, string(bs))
+	})
+
+	t.Run("should encode violations of values with children under the errors key", func(t *testing.T) {
+		violations := validation.Validate(treeTester{Tags: []string{""}}, validation.Fields{
+			"Tags": validation.Constraints{
+				constraints.MinLength(2),
+				validation.Elements{constraints.Required},
+			},
+		})
+
+		bs, err := json.Marshal(validation.NewViolationTree(violations))
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"tags": {
+				"_errors": ["minimum length not met"],
+				"0": ["a value is required"]
+			}
+		}`, string(bs))
+	})
+
+	t.Run("should encode violations of the root value", func(t *testing.T) {
+		bs, err := json.Marshal(validation.NewViolationTree(validation.Validate("", constraints.Required)))
+		require.NoError(t, err)
+		assert.JSONEq(t, `["a value is required"]`, string(bs))
+	})
+
+	t.Run("should encode an empty tree as an empty object", func(t *testing.T) {
+		bs, err := json.Marshal(validation.NewViolationTree(nil))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(bs))
+	})
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/seeruk/go-validation/protobuf"
 	"github.com/seeruk/go-validation/validationpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -17,33 +19,112 @@ import (
 // path that's output.
 const DefaultNameStructTag = "validation"
 
+// Codes of the violations produced by this package. See the constraints package for the codes of
+// the built-in constraints.
+const (
+	// CodeCancelled is the code of the violation returned if validation is cancelled.
+	CodeCancelled = "cancelled"
+	// CodeInvalidJSON is the code of the violation returned by JSONBinder if the JSON it's given
+	// isn't valid.
+	CodeInvalidJSON = "invalid_json"
+	// CodeInvalidType is the code of violations returned by JSONBinder for JSON values that are not
+	// of the type they're decoded into.
+	CodeInvalidType = "invalid_type"
+	// CodeKind is the code of violations returned by ShouldBe, i.e. when a value is not of an
+	// allowed kind.
+	CodeKind = "kind"
+	// CodeUnknownField is the code of violations returned by JSONBinder for JSON object members that
+	// don't match any field of the struct they're decoded into.
+	CodeUnknownField = "unknown_field"
+)
+
 // Validate executes the given constraint(s) against the given value, returning any violations of
 // those constraints.
 func Validate(value any, constraints ...Constraint) []ConstraintViolation {
 	return ValidateContext(NewContext(value), constraints...)
 }
 
+// ValidateCtx is exactly like Validate, except the given context.Context is attached to the
+// validation Context, allowing constraints to observe deadlines and cancellation. If the context is
+// cancelled before validation completes, no violations are returned, and the context's error is
+// returned instead of partial results.
+func ValidateCtx(ctx context.Context, value any, constraints ...Constraint) ([]ConstraintViolation, error) {
+	return validate(NewContext(value).WithContext(ctx), constraints...)
+}
+
 // CreateValidateFunc allows the caller to create a customised validate function, using the given
 // option(s), allowing them to avoid manually creating a context and using the simpler API while
 // maintaining the ability to customise the validation context.
-// TODO: Any more options to add?
-func CreateValidateFunc(structTag string) func(value any, constraints ...Constraint) []ConstraintViolation {
+func CreateValidateFunc(structTag string, opts ...Option) func(value any, constraints ...Constraint) []ConstraintViolation {
 	return func(value any, constraints ...Constraint) []ConstraintViolation {
-		ctx := NewContext(value)
+		ctx := NewContext(value, opts...)
 		ctx.StructTag = structTag
 		return ValidateContext(ctx, constraints...)
 	}
 }
 
+// Option is a function that configures a Context, used with NewContext and CreateValidateFunc.
+type Option func(ctx *Context)
+
+// WithPathFormatter returns an Option that sets the PathFormatter used to render the paths of any
+// violations, e.g. FormatJSONPointer.
+func WithPathFormatter(formatter PathFormatter) Option {
+	return func(ctx *Context) {
+		ctx.PathFormatter = formatter
+	}
+}
+
 // ValidateContext is exactly like Validate, except it doesn't create a Context for you. This allows
 // for more granular configuration provided by the Context type (and means we can avoid creating a
 // Validator struct type to do this).
+//
+// If the given Context has a cancelled context.Context attached, a single violation is returned
+// stating that validation was cancelled, instead of partial results.
 func ValidateContext(ctx Context, constraints ...Constraint) []ConstraintViolation {
+	violations, err := validate(ctx, constraints...)
+	if err != nil {
+		return TranslateViolations(ctx.Translator, []ConstraintViolation{
+			ctx.CodedViolation(CodeCancelled, "validation was cancelled", map[string]any{
+				"error": err.Error(),
+			}),
+		})
+	}
+
+	return violations
+}
+
+// validate runs the given constraints against the given Context, returning an error instead of any
+// violations if the Context is cancelled at any point before validation completes.
+func validate(ctx Context, constraints ...Constraint) ([]ConstraintViolation, error) {
 	if !ctx.Value().Node.IsValid() {
 		panic("validation: expected a valid type to be given (i.e. valid to Go's reflect library)")
 	}
 
-	return Constraints(constraints).Violations(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ctx.GroupSequence) > 0 {
+		return validateGroupSequence(ctx, constraints...)
+	}
+
+	cc := Constraints(constraints)
+	if ctx.Deep {
+		cc = append(cc[:len(cc):len(cc)], deepConstraint{})
+	}
+
+	violations := cc.Violations(ctx)
+	if ctx.Deep {
+		violations = uniqueViolations(violations)
+	}
+
+	// Constraints stop descending once cancelled, so anything we have at this point may well be
+	// incomplete. It's better to be explicit about that than to return partial results.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return TranslateViolations(ctx.Translator, violations), nil
 }
 
 // Constraint represents a type that will validate a value and/or adjust the validation scope for
@@ -112,11 +193,18 @@ func (k PathKind) String() string {
 
 // ConstraintViolation contains information to highlight a value failing to fulfil the requirements
 // of a Constraint. It contains information to find the value that is failing, and how to resolve
-// the violation.
+// the violation. Custom is true if the message isn't the usual one for the violation's code (e.g.
+// it was set using constraints.Details), in which case it's never translated, see Translator.
 type ConstraintViolation struct {
 	Path     string         `json:"path"`
+	Segments []PathSegment  `json:"segments,omitempty"`
 	PathKind PathKind       `json:"path_kind"`
+	Severity Severity       `json:"severity"`
+	Code     string         `json:"code,omitempty"`
 	Message  string         `json:"message"`
+	Template string         `json:"template,omitempty"`
+	Custom   bool           `json:"custom,omitempty"`
+	Group    string         `json:"group,omitempty"`
 	Details  map[string]any `json:"details,omitempty"`
 }
 
@@ -125,14 +213,89 @@ type Context struct {
 	PathKind  PathKind
 	StructTag string
 	Values    []Value
+
+	// FailFast stops validation entirely as soon as the first error-level violation is found,
+	// returning only that violation (and any warnings found before it). Useful for large values
+	// where all that matters is whether they're valid.
+	FailFast bool
+	// BailPerPath skips any remaining constraints on a path once an earlier constraint on that
+	// same path has produced an error-level violation (e.g. Required, followed by MinLength).
+	BailPerPath bool
+	// PathFormatter is used to render the Path of violations. If nil, FormatPath is used.
+	PathFormatter PathFormatter
+	// Deep enables deep validation. The constraints of any Validatable value reached via struct
+	// fields, array and slice elements, map values, or pointers are applied automatically, as well
+	// as any constraints given explicitly. Duplicate violations are removed.
+	Deep bool
+	// PreserveOrder stops violations from being sorted by path, so that they're returned in the
+	// order they were produced in instead, i.e. the order constraints were given in, with struct
+	// fields in declaration order, and map keys sorted. See Constraints.
+	PreserveOrder bool
+	// Workers is the maximum number of goroutines used to validate the items of a collection (the
+	// elements of an array, slice, or map, the keys of a map, or the fields of a struct) that has at
+	// least ParallelThreshold items. Violations are returned in exactly the same order as they would
+	// be if the items were validated sequentially. Values less than 2 disable parallel validation.
+	Workers int
+	// ParallelThreshold is the minimum number of items a collection must have to be validated in
+	// parallel, see Workers. Small collections are usually faster to validate sequentially.
+	ParallelThreshold int
+	// Groups are the names of the active groups. Only constraints in an active group are applied,
+	// see Group. If empty, only the default group is active (see DefaultGroup), so constraints that
+	// are in other groups are skipped.
+	Groups []string
+	// GroupSequence is a list of group names to validate in turn. Each group is validated on its own,
+	// and validation stops after the first group that produces any error-level violations, e.g. so
+	// that expensive constraints only run once cheaper ones have passed. Overrides Groups if set.
+	GroupSequence []string
+	// Mask restricts validation to the values within it, e.g. for partial updates. If nil, all
+	// values are validated. See FieldMask.
+	Mask *FieldMask
+	// Translator is used to translate the messages of any violations once validation is complete,
+	// e.g. into the locale of the request being validated. If nil, messages aren't translated.
+	Translator Translator
+
+	// ctx is the context.Context attached to this Context, see Context and WithContext.
+	ctx context.Context
+	// outsideMask is true if the current value isn't within the FieldMask that was set on the
+	// Context, in which case it shouldn't be validated.
+	outsideMask bool
+	// group is the name of the group the constraints being applied are in, see Group. Empty if
+	// they're in the default group.
+	group string
 }
 
-// NewContext returns a new Context, with a Value created for the given any value.
-func NewContext(value any) Context {
+// NewContext returns a new Context, with a Value created for the given any value, configured using
+// the given option(s).
+func NewContext(value any, opts ...Option) Context {
 	ctx := Context{StructTag: DefaultNameStructTag}
+	for _, opt := range opts {
+		opt(&ctx)
+	}
+
 	return ctx.WithValue("", reflect.ValueOf(value))
 }
 
+// Context returns the context.Context attached to this Context. If one hasn't been attached, then
+// context.Background is returned.
+func (c *Context) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// Err returns the error of the context.Context attached to this Context, if any. A non-nil error
+// means validation has been cancelled (or its deadline has been exceeded), and Constraints should
+// stop doing any further work.
+func (c *Context) Err() error {
+	if c.ctx == nil {
+		return nil
+	}
+
+	return c.ctx.Err()
+}
+
 // Value gets the current value (the last value in Values).
 func (c *Context) Value() Value {
 	if len(c.Values) == 0 {
@@ -146,26 +309,80 @@ func (c *Context) Value() Value {
 // on the Context. If a custom violation is needed, one can always be made using the information on
 // the Context manually.
 func (c *Context) Violation(message string, details map[string]any) ConstraintViolation {
-	pathBuilder := strings.Builder{}
-	pathBuilder.WriteString(".")
-
-	// The first is skipped because if validation.Validate was used it won't have a name, we'll just
-	// refer to it as ".".
-	for i, val := range c.Values[1:] {
-		if val.Name != "" && i != 0 {
-			pathBuilder.WriteString(".")
-		}
-		pathBuilder.WriteString(val.Name)
-	}
+	segments := c.Segments()
 
 	return ConstraintViolation{
-		Path:     pathBuilder.String(),
+		Path:     c.formatPath(segments),
+		Segments: segments,
 		PathKind: c.PathKind,
 		Message:  message,
+		Group:    c.group,
 		Details:  details,
 	}
 }
 
+// CodedViolation is exactly like Violation, except the violation is given a code. Codes are stable,
+// machine-readable identifiers for the kind of violation, allowing clients to decide how to handle
+// a violation without relying on its message, which may change.
+func (c *Context) CodedViolation(code, message string, details map[string]any) ConstraintViolation {
+	violation := c.Violation(message, details)
+	violation.Code = code
+
+	return violation
+}
+
+// Segments returns the path to the current value as a slice of PathSegment, built up from the
+// values on this Context. The returned slice is a copy, and can be safely modified.
+func (c *Context) Segments() []PathSegment {
+	if len(c.Values) < 2 {
+		return nil
+	}
+
+	// The first is skipped because it's the root value, and so isn't reached via any segment.
+	segments := make([]PathSegment, 0, len(c.Values)-1)
+	for _, val := range c.Values[1:] {
+		segments = append(segments, val.Segment)
+	}
+
+	return segments
+}
+
+// path builds the path to the current value from the values on this Context.
+func (c *Context) path() string {
+	return c.formatPath(c.Segments())
+}
+
+// formatPath renders the given segments using this Context's PathFormatter.
+func (c *Context) formatPath(segments []PathSegment) string {
+	if c.PathFormatter == nil {
+		return FormatPath(segments)
+	}
+
+	return c.PathFormatter(segments)
+}
+
+// shouldStop returns true if no further constraints should be run, given the violations found so
+// far, either because validation has been cancelled, or because we're failing fast.
+func (c *Context) shouldStop(violations []ConstraintViolation) bool {
+	return c.Err() != nil || (c.FailFast && HasErrors(violations))
+}
+
+// limit trims the given violations down to the first error-level violation if we're failing fast.
+// Any warnings found before that violation are kept.
+func (c *Context) limit(violations []ConstraintViolation) []ConstraintViolation {
+	if !c.FailFast {
+		return violations
+	}
+
+	for i, violation := range violations {
+		if violation.Severity == SeverityError {
+			return violations[:i+1]
+		}
+	}
+
+	return violations
+}
+
 // WithPathKind returns a shallow copy of this Context with the given PathKind assigned, not
 // modifying the original Context.
 func (c Context) WithPathKind(pathKind PathKind) Context {
@@ -173,12 +390,66 @@ func (c Context) WithPathKind(pathKind PathKind) Context {
 	return c
 }
 
+// WithContext returns a shallow copy of this Context with the given context.Context attached, not
+// modifying the original Context. The given context.Context must not be nil.
+func (c Context) WithContext(ctx context.Context) Context {
+	if ctx == nil {
+		panic("validation: nil context.Context given to WithContext")
+	}
+
+	c.ctx = ctx
+	return c
+}
+
 // WithValue returns a shallow copy of this Context with the given value assigned, not modifying the
-// original Context.
+// original Context. The value is treated as being reached via a field with the given name. Where
+// possible, use WithField, WithIndex, or WithKey instead, so that the path to the value is more
+// accurately described.
 func (c Context) WithValue(name string, val reflect.Value) Context {
+	return c.withSegment(PathSegment{Kind: PathSegmentKindField, Name: name}, val)
+}
+
+// WithField returns a shallow copy of this Context with the given struct field value assigned, not
+// modifying the original Context. The field is the Go name of the field, and name is the name that
+// should be output in paths (see FieldName).
+func (c Context) WithField(field, name string, val reflect.Value) Context {
+	return c.withSegment(FieldSegment(field, name), val)
+}
+
+// WithIndex returns a shallow copy of this Context with the given array or slice element value
+// assigned, not modifying the original Context.
+func (c Context) WithIndex(index int, val reflect.Value) Context {
+	return c.withSegment(IndexSegment(index), val)
+}
+
+// WithKey returns a shallow copy of this Context with the value found under the given map key
+// assigned, not modifying the original Context. When validating a map key itself, the key should be
+// given as both arguments, and the PathKind set to PathKindKey.
+func (c Context) WithKey(key, val reflect.Value) Context {
+	var keyValue any
+	if key.IsValid() && key.CanInterface() {
+		keyValue = key.Interface()
+	} else {
+		keyValue = valueString(key)
+	}
+
+	return c.withSegment(KeySegment(keyValue), val)
+}
+
+// withSegment returns a shallow copy of this Context with the given value, reached via the given
+// segment, assigned.
+func (c Context) withSegment(segment PathSegment, val reflect.Value) Context {
 	value := Value{
-		Name: name,
-		Node: val,
+		Name:    segment.String(),
+		Segment: segment,
+		Node:    val,
+	}
+
+	// The root value isn't reached via a segment, so it's always within the mask.
+	if c.Mask != nil && len(c.Values) > 0 {
+		var ok bool
+		c.Mask, ok = c.Mask.child(segment)
+		c.outsideMask = !ok
 	}
 
 	// TODO: This would be far more efficient with a linked list probably?
@@ -189,8 +460,9 @@ func (c Context) WithValue(name string, val reflect.Value) Context {
 // Value represents a value to be validated, and it's "name" (i.e. something we can use to build up
 // a path to the value).
 type Value struct {
-	Name string
-	Node reflect.Value
+	Name    string
+	Segment PathSegment
+	Node    reflect.Value
 }
 
 // FieldName returns the output name for a field of the given field name. The provided Context's
@@ -201,22 +473,26 @@ func FieldName(ctx Context, fieldName string) string {
 	rtyp := UnwrapType(rval.Type())
 	MustBe(rtyp, reflect.Struct)
 
-	field, ok := rtyp.FieldByName(fieldName)
+	fi, ok := cachedStructInfo(rtyp).fields[fieldName]
 	if !ok {
 		// TODO: More info, like type? Let's see what the stack trace looks like first.
 		panic(fmt.Sprintf("validation: field '%s' does not exist", fieldName))
 	}
 
-	name := fieldName
+	return fi.name(ctx.StructTag)
+}
+
+// structFieldName returns the name of the given struct field as it should be output in paths,
+// taking the given struct tag into account, if it's set.
+func structFieldName(field reflect.StructField, structTag string) string {
+	name := field.Name
 
-	if ctx.StructTag != "" {
-		tag := field.Tag.Get(ctx.StructTag)
+	if structTag != "" {
+		tag := field.Tag.Get(structTag)
 		if tag == "-" {
 			name = ""
 		} else if tag != "" {
-			// Split should never return an empty slice as long as the separator is not empty.
-			split := strings.Split(tag, ",")
-			name = split[0]
+			name, _, _ = strings.Cut(tag, ",")
 		}
 	}
 
@@ -266,7 +542,7 @@ func ShouldBe(ctx Context, typ reflect.Type, kinds ...reflect.Kind) []Constraint
 	}
 
 	return []ConstraintViolation{
-		ctx.Violation("value should be of one of the allowed kinds", map[string]any{
+		ctx.CodedViolation(CodeKind, "value should be of one of the allowed kinds", map[string]any{
 			"allowed_kinds": kindNames,
 		}),
 	}
@@ -333,12 +609,26 @@ func ConstraintViolationsFromProto(protoViolations *validationpb.ConstraintViola
 // ConstraintViolationToProto converts a ConstraintViolation into the ProtoBuf representation of
 // that ConstraintViolation.
 func ConstraintViolationToProto(violation ConstraintViolation) *validationpb.ConstraintViolation {
+	var segments []*validationpb.PathSegment
+	if len(violation.Segments) > 0 {
+		segments = make([]*validationpb.PathSegment, 0, len(violation.Segments))
+		for _, segment := range violation.Segments {
+			segments = append(segments, PathSegmentToProto(segment))
+		}
+	}
+
 	return &validationpb.ConstraintViolation{
-		Path: violation.Path,
+		Path:     violation.Path,
+		Segments: segments,
 		// Currently these enum values are both just numbers, and both start at the same number,
 		// and the values are in the same order.
 		PathKind: validationpb.PathKind(violation.PathKind),
+		Severity: validationpb.Severity(violation.Severity),
+		Code:     violation.Code,
 		Message:  violation.Message,
+		Template: violation.Template,
+		Custom:   violation.Custom,
+		Group:    violation.Group,
 		Details:  protobuf.MapToStruct(violation.Details),
 	}
 }
@@ -355,18 +645,89 @@ func ConstraintViolationFromProto(protoViolation *validationpb.ConstraintViolati
 		details = protoViolation.Details.AsMap()
 	}
 
+	var segments []PathSegment
+	if len(protoViolation.Segments) > 0 {
+		segments = make([]PathSegment, 0, len(protoViolation.Segments))
+		for _, protoSegment := range protoViolation.Segments {
+			segments = append(segments, PathSegmentFromProto(protoSegment))
+		}
+	}
+
 	return ConstraintViolation{
 		Path:     protoViolation.Path,
+		Segments: segments,
 		PathKind: PathKind(protoViolation.PathKind),
+		Severity: Severity(protoViolation.Severity),
+		Code:     protoViolation.Code,
 		Message:  protoViolation.Message,
+		Template: protoViolation.Template,
+		Custom:   protoViolation.Custom,
+		Group:    protoViolation.Group,
 		Details:  details,
 	}
 }
 
-// ViolationsToStatus returns the given set of constraint violations as a gRPC status.
-func ViolationsToStatus(violations []ConstraintViolation) *status.Status {
-	sts, err := status.New(codes.InvalidArgument, "validation failed").
-		WithDetails(ConstraintViolationsToProto(violations))
+// PathSegmentToProto converts a PathSegment into the ProtoBuf representation of that PathSegment.
+func PathSegmentToProto(segment PathSegment) *validationpb.PathSegment {
+	protoSegment := &validationpb.PathSegment{
+		// As with PathKind, these enum values are in the same order, starting at the same number.
+		Kind: validationpb.PathSegmentKind(segment.Kind),
+	}
+
+	switch segment.Kind {
+	case PathSegmentKindField:
+		protoSegment.Name = segment.Name
+		protoSegment.Field = segment.Field
+	case PathSegmentKindIndex:
+		protoSegment.Index = int64(segment.Index)
+	case PathSegmentKindKey:
+		protoSegment.Key = protobuf.ToValue(segment.Key)
+	}
+
+	return protoSegment
+}
+
+// PathSegmentFromProto converts a ProtoBuf PathSegment into the native Go representation. Map keys
+// are converted to whatever type the ProtoBuf Value represents (e.g. numbers are float64).
+func PathSegmentFromProto(protoSegment *validationpb.PathSegment) PathSegment {
+	if protoSegment == nil {
+		return PathSegment{}
+	}
+
+	segment := PathSegment{
+		Kind:  PathSegmentKind(protoSegment.Kind),
+		Name:  protoSegment.Name,
+		Field: protoSegment.Field,
+		Index: int(protoSegment.Index),
+	}
+
+	if protoSegment.Key != nil {
+		segment.Key = protoSegment.Key.AsInterface()
+	}
+
+	return segment
+}
+
+// ViolationsToStatus returns the given set of constraint violations as a gRPC status. The status
+// only fails (with codes.InvalidArgument, by default) if there are error-level violations, in which
+// case any warnings are included in the status details too. Otherwise, an OK status is returned, and
+// as gRPC doesn't allow details on OK statuses, any warnings need to be returned some other way. The
+// code, message, and details of the status can be configured using the given option(s).
+func ViolationsToStatus(violations []ConstraintViolation, opts ...StatusOption) *status.Status {
+	if !HasErrors(violations) {
+		return status.New(codes.OK, "")
+	}
+
+	return violationsStatus(violations, opts...)
+}
+
+// violationsStatus returns the given set of constraint violations as a failed gRPC status, whether
+// or not any of them are errors, see ViolationsToStatus.
+func violationsStatus(violations []ConstraintViolation, opts ...StatusOption) *status.Status {
+	o := newStatusOptions(opts...)
+
+	sts, err := status.New(o.code, o.message(violations)).
+		WithDetails(o.details(violations)...)
 	if err != nil {
 		return status.New(codes.Internal, "failed to generate status for validation failures")
 	}
@@ -375,16 +736,29 @@ func ViolationsToStatus(violations []ConstraintViolation) *status.Status {
 }
 
 // ViolationsFromStatus returns the constraint violations from the given gRPC status. If the status
-// doesn't contain any constraint violations, an empty slice is returned.
+// doesn't contain any validationpb constraint violations, but does contain a google.rpc.BadRequest
+// detail (e.g. from a service not using this package), its field violations are returned instead.
+// If the status doesn't contain either, an empty slice is returned.
 func ViolationsFromStatus(sts *status.Status) []ConstraintViolation {
 	details := sts.Details()
 	violations := make([]ConstraintViolation, 0, len(details))
+
+	var badRequests []*errdetails.BadRequest
 	for _, detail := range details {
 		switch detail := detail.(type) {
 		case *validationpb.ConstraintViolations:
 			violations = append(violations, ConstraintViolationsFromProto(detail)...)
 		case *validationpb.ConstraintViolation:
 			violations = append(violations, ConstraintViolationFromProto(detail))
+		case *errdetails.BadRequest:
+			badRequests = append(badRequests, detail)
+		}
+	}
+
+	// Statuses made by ViolationsToStatus may hold the same violations in both forms.
+	if len(violations) == 0 {
+		for _, badRequest := range badRequests {
+			violations = append(violations, violationsFromBadRequest(badRequest)...)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package validation_test
 
 import (
+	"context"
 	"math"
 	"reflect"
 	"regexp"
@@ -46,6 +47,99 @@ func TestValidateContext(t *testing.T) {
 			validation.ValidateContext(validation.NewContext(nil), constraints.Required)
 		})
 	})
+
+	t.Run("should return only the first violation when failing fast", func(t *testing.T) {
+		ctx := validation.NewContext(testSubject1{})
+		ctx.FailFast = true
+
+		violations := validation.ValidateContext(ctx, testSubject1{}.Constraints())
+		assert.Len(t, violations, 1)
+	})
+
+	t.Run("should return fewer violations when bailing per path", func(t *testing.T) {
+		subject := testSubject1{Text: "nope"}
+
+		all := validation.Validate(subject, subject.Constraints())
+
+		ctx := validation.NewContext(subject)
+		ctx.BailPerPath = true
+
+		bailed := validation.ValidateContext(ctx, subject.Constraints())
+		assert.NotEmpty(t, bailed)
+		assert.Less(t, len(bailed), len(all))
+	})
+
+	t.Run("should return a single cancellation violation if the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		vctx := validation.NewContext("").WithContext(ctx)
+
+		violations := validation.ValidateContext(vctx, constraints.Required, constraints.Required)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "validation was cancelled", violations[0].Message)
+		assert.Equal(t, map[string]any{"error": context.Canceled.Error()}, violations[0].Details)
+	})
+}
+
+func TestValidateCtx(t *testing.T) {
+	t.Run("should return violations if the value is invalid", func(t *testing.T) {
+		violations, err := validation.ValidateCtx(context.Background(), 0, constraints.Required)
+		require.NoError(t, err)
+		assert.Len(t, violations, 1)
+	})
+
+	t.Run("should return no violations if the value is valid", func(t *testing.T) {
+		violations, err := validation.ValidateCtx(context.Background(), 1, constraints.Required)
+		require.NoError(t, err)
+		assert.Len(t, violations, 0)
+	})
+
+	t.Run("should make the given context available to constraints", func(t *testing.T) {
+		type key struct{}
+
+		ctx := context.WithValue(context.Background(), key{}, "hello")
+
+		var value any
+		_, err := validation.ValidateCtx(ctx, 1, validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			value = ctx.Context().Value(key{})
+			return nil
+		}))
+
+		require.NoError(t, err)
+		assert.Equal(t, "hello", value)
+	})
+
+	t.Run("should return the context's error, and no violations, if the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		violations, err := validation.ValidateCtx(ctx, 0, constraints.Required)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, violations)
+	})
+
+	t.Run("should return the context's error if the context is cancelled during validation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		cancelling := validation.ConstraintFunc(func(ctx validation.Context) []validation.ConstraintViolation {
+			cancel()
+			return []validation.ConstraintViolation{ctx.Violation("test", nil)}
+		})
+
+		violations, err := validation.ValidateCtx(ctx, 0, cancelling, constraints.Required)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, violations)
+	})
+
+	t.Run("should return the context's error if the context's deadline is exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		_, err := validation.ValidateCtx(ctx, 0, constraints.Required)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
 }
 
 func TestConstraintFunc_Violations(t *testing.T) {
@@ -103,6 +197,77 @@ func TestNewContext(t *testing.T) {
 		ctx := validation.NewContext("hello")
 		assert.Equal(t, validation.DefaultNameStructTag, ctx.StructTag)
 	})
+
+	t.Run("should apply the given options", func(t *testing.T) {
+		ctx := validation.NewContext("hello", validation.WithPathFormatter(validation.FormatJSONPointer))
+		assert.NotNil(t, ctx.PathFormatter)
+	})
+}
+
+func TestCreateValidateFunc(t *testing.T) {
+	t.Run("should use the given struct tag", func(t *testing.T) {
+		type subject struct {
+			Name string `yaml:"display_name"`
+		}
+
+		validate := validation.CreateValidateFunc("yaml")
+		violations := validate(subject{}, validation.Fields{
+			"Name": constraints.Required,
+		})
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, ".display_name", violations[0].Path)
+	})
+
+	t.Run("should render paths using the given path formatter", func(t *testing.T) {
+		type subject struct {
+			Labels map[string]string `json:"labels"`
+		}
+
+		validate := validation.CreateValidateFunc("json", validation.WithPathFormatter(validation.FormatJSONPointer))
+		violations := validate(subject{Labels: map[string]string{"app/name": ""}}, validation.Fields{
+			"Labels": validation.Elements{constraints.Required},
+		})
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, "/labels/app~1name", violations[0].Path)
+	})
+}
+
+func TestContext_Context(t *testing.T) {
+	t.Run("should return context.Background if no context has been attached", func(t *testing.T) {
+		ctx := validation.NewContext("hello")
+		assert.Equal(t, context.Background(), ctx.Context())
+	})
+
+	t.Run("should return the attached context", func(t *testing.T) {
+		type key struct{}
+
+		stdCtx := context.WithValue(context.Background(), key{}, "world")
+
+		ctx := validation.NewContext("hello").WithContext(stdCtx)
+		assert.Equal(t, stdCtx, ctx.Context())
+	})
+}
+
+func TestContext_Err(t *testing.T) {
+	t.Run("should return nil if no context has been attached", func(t *testing.T) {
+		ctx := validation.NewContext("hello")
+		assert.NoError(t, ctx.Err())
+	})
+
+	t.Run("should return nil if the attached context has not been cancelled", func(t *testing.T) {
+		ctx := validation.NewContext("hello").WithContext(context.Background())
+		assert.NoError(t, ctx.Err())
+	})
+
+	t.Run("should return the attached context's error if it has been cancelled", func(t *testing.T) {
+		stdCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		ctx := validation.NewContext("hello").WithContext(stdCtx)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
 }
 
 func TestContext_Value(t *testing.T) {
@@ -158,6 +323,34 @@ func TestContext_Violation(t *testing.T) {
 		assert.Equal(t, ".Layer1.Layer2", violation.Path)
 	})
 
+	t.Run("should build up the path segments on the violation", func(t *testing.T) {
+		value := map[string][]struct{ Name string }{
+			"items": {{Name: "Test"}},
+		}
+
+		ctx := validation.NewContext(value)
+		ctx = ctx.WithKey(reflect.ValueOf("items"), reflect.ValueOf(value["items"]))
+		ctx = ctx.WithIndex(0, reflect.ValueOf(value["items"][0]))
+		ctx = ctx.WithField("Name", "name", reflect.ValueOf(value["items"][0].Name))
+
+		violation := ctx.Violation("", nil)
+
+		assert.Equal(t, ".items.[0].name", violation.Path)
+		assert.Equal(t, []validation.PathSegment{
+			validation.KeySegment("items"),
+			validation.IndexSegment(0),
+			validation.FieldSegment("Name", "name"),
+		}, violation.Segments)
+	})
+
+	t.Run("should not set any segments for violations on the root value", func(t *testing.T) {
+		ctx := validation.NewContext("")
+		violation := ctx.Violation("", nil)
+
+		assert.Equal(t, ".", violation.Path)
+		assert.Nil(t, violation.Segments)
+	})
+
 	t.Run("should set the PathKind from the Context on the returned violation", func(t *testing.T) {
 		ctx := validation.NewContext("")
 		violation := ctx.Violation("", nil)
@@ -171,6 +364,21 @@ func TestContext_Violation(t *testing.T) {
 	})
 }
 
+func TestContext_CodedViolation(t *testing.T) {
+	t.Run("should return a new violation with the given code, message, and details", func(t *testing.T) {
+		details := map[string]any{
+			"some": "value",
+		}
+
+		ctx := validation.NewContext("")
+		violation := ctx.CodedViolation("test_code", "test violation", details)
+
+		assert.Equal(t, "test_code", violation.Code)
+		assert.Equal(t, "test violation", violation.Message)
+		assert.Equal(t, details, violation.Details)
+	})
+}
+
 func TestContext_WithPathKind(t *testing.T) {
 	t.Run("should return a copy of the original context with the given PathKind", func(t *testing.T) {
 		oldCtx := validation.NewContext("hello")
@@ -183,6 +391,25 @@ func TestContext_WithPathKind(t *testing.T) {
 	})
 }
 
+func TestContext_WithContext(t *testing.T) {
+	t.Run("should return a copy of the original context with the given context attached", func(t *testing.T) {
+		stdCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		oldCtx := validation.NewContext("hello")
+		newCtx := oldCtx.WithContext(stdCtx)
+
+		assert.Equal(t, context.Background(), oldCtx.Context())
+		assert.Equal(t, stdCtx, newCtx.Context())
+	})
+
+	t.Run("should panic if given a nil context", func(t *testing.T) {
+		assert.Panics(t, func() {
+			validation.NewContext("hello").WithContext(nil)
+		})
+	})
+}
+
 func TestContext_WithValue(t *testing.T) {
 	t.Run("should return a copy of the original context with the given value", func(t *testing.T) {
 		oldCtx := validation.NewContext("hello")
@@ -191,6 +418,55 @@ func TestContext_WithValue(t *testing.T) {
 		assert.NotEmpty(t, oldCtx, newCtx)
 		assert.Equal(t, newCtx.Value().Node.Interface(), "world")
 	})
+
+	t.Run("should treat the value as being reached via a field with the given name", func(t *testing.T) {
+		ctx := validation.NewContext("hello").WithValue("subject", reflect.ValueOf("world"))
+		assert.Equal(t, []validation.PathSegment{{Kind: validation.PathSegmentKindField, Name: "subject"}}, ctx.Segments())
+	})
+}
+
+func TestContext_WithField(t *testing.T) {
+	t.Run("should return a copy of the original context with the given field value", func(t *testing.T) {
+		oldCtx := validation.NewContext(struct{ Foo string }{Foo: "bar"})
+		newCtx := oldCtx.WithField("Foo", "foo", reflect.ValueOf("bar"))
+
+		assert.Len(t, oldCtx.Values, 1)
+		assert.Equal(t, "bar", newCtx.Value().Node.Interface())
+		assert.Equal(t, "foo", newCtx.Value().Name)
+		assert.Equal(t, []validation.PathSegment{validation.FieldSegment("Foo", "foo")}, newCtx.Segments())
+	})
+}
+
+func TestContext_WithIndex(t *testing.T) {
+	t.Run("should return a copy of the original context with the given element value", func(t *testing.T) {
+		oldCtx := validation.NewContext([]string{"hello", "world"})
+		newCtx := oldCtx.WithIndex(1, reflect.ValueOf("world"))
+
+		assert.Len(t, oldCtx.Values, 1)
+		assert.Equal(t, "world", newCtx.Value().Node.Interface())
+		assert.Equal(t, "[1]", newCtx.Value().Name)
+		assert.Equal(t, []validation.PathSegment{validation.IndexSegment(1)}, newCtx.Segments())
+	})
+}
+
+func TestContext_WithKey(t *testing.T) {
+	t.Run("should return a copy of the original context with the given map value", func(t *testing.T) {
+		oldCtx := validation.NewContext(map[int]string{12: "hello"})
+		newCtx := oldCtx.WithKey(reflect.ValueOf(12), reflect.ValueOf("hello"))
+
+		assert.Len(t, oldCtx.Values, 1)
+		assert.Equal(t, "hello", newCtx.Value().Node.Interface())
+		assert.Equal(t, "12", newCtx.Value().Name)
+		assert.Equal(t, []validation.PathSegment{validation.KeySegment(12)}, newCtx.Segments())
+	})
+
+	t.Run("should retain the original type of the key", func(t *testing.T) {
+		ctx := validation.NewContext(map[int]string{12: "hello"}).
+			WithKey(reflect.ValueOf(12), reflect.ValueOf("hello"))
+
+		require.Len(t, ctx.Segments(), 1)
+		assert.IsType(t, 12, ctx.Segments()[0].Key)
+	})
 }
 
 func TestFieldName(t *testing.T) {
@@ -334,6 +610,31 @@ func TestConstraintViolationsToProto(t *testing.T) {
 		assert.Len(t, validation.ConstraintViolationsToProto(violations).Violations, len(violations))
 	})
 
+	t.Run("should include path segments in resulting proto violations", func(t *testing.T) {
+		violations := []validation.ConstraintViolation{
+			{
+				Path: ".test.[0].nil",
+				Segments: []validation.PathSegment{
+					validation.FieldSegment("Test", "test"),
+					validation.IndexSegment(0),
+					validation.KeySegment(nil),
+				},
+			},
+		}
+
+		protoViolations := validation.ConstraintViolationsToProto(violations)
+
+		require.Len(t, protoViolations.Violations, 1)
+		require.Len(t, protoViolations.Violations[0].Segments, 3)
+		assert.Equal(t, validationpb.PathSegmentKind_PATH_SEGMENT_KIND_FIELD, protoViolations.Violations[0].Segments[0].Kind)
+		assert.Equal(t, "test", protoViolations.Violations[0].Segments[0].Name)
+		assert.Equal(t, "Test", protoViolations.Violations[0].Segments[0].Field)
+		assert.Equal(t, validationpb.PathSegmentKind_PATH_SEGMENT_KIND_INDEX, protoViolations.Violations[0].Segments[1].Kind)
+		assert.Equal(t, int64(0), protoViolations.Violations[0].Segments[1].Index)
+		assert.Equal(t, validationpb.PathSegmentKind_PATH_SEGMENT_KIND_KEY, protoViolations.Violations[0].Segments[2].Kind)
+		assert.Equal(t, structpb.NewNullValue(), protoViolations.Violations[0].Segments[2].Key)
+	})
+
 	t.Run("should return the same information in resulting proto violations", func(t *testing.T) {
 		path := ".test.violation.[0].to.proto"
 		pathKind := validation.PathKindKey
@@ -372,7 +673,10 @@ func TestConstraintViolationsFromProto(t *testing.T) {
 			{
 				Path:     ".test",
 				PathKind: validation.PathKindKey,
+				Code:     "test_code",
 				Message:  "test violation",
+				Template: "test {hello}",
+				Custom:   true,
 				Details: map[string]any{
 					"hello": "world",
 				},
@@ -382,6 +686,34 @@ func TestConstraintViolationsFromProto(t *testing.T) {
 		output := validation.ConstraintViolationsFromProto(validation.ConstraintViolationsToProto(input))
 		assert.Equal(t, input, output)
 	})
+
+	t.Run("should return the same path segments in resulting violations", func(t *testing.T) {
+		input := []validation.ConstraintViolation{
+			{
+				Path: ".items.[2].hello",
+				Segments: []validation.PathSegment{
+					validation.FieldSegment("Items", "items"),
+					validation.IndexSegment(2),
+					validation.KeySegment("hello"),
+				},
+				Message: "test violation",
+			},
+		}
+
+		output := validation.ConstraintViolationsFromProto(validation.ConstraintViolationsToProto(input))
+		assert.Equal(t, input, output)
+	})
+}
+
+func TestPathSegmentFromProto(t *testing.T) {
+	t.Run("should return an empty segment for a nil proto segment", func(t *testing.T) {
+		assert.Equal(t, validation.PathSegment{}, validation.PathSegmentFromProto(nil))
+	})
+
+	t.Run("should convert numeric keys to float64 values", func(t *testing.T) {
+		segment := validation.PathSegmentFromProto(validation.PathSegmentToProto(validation.KeySegment(12)))
+		assert.Equal(t, validation.KeySegment(float64(12)), segment)
+	})
 }
 
 func TestViolationsToStatus(t *testing.T) {
@@ -427,6 +759,29 @@ func TestViolationsToStatus(t *testing.T) {
 			assert.Equal(t, protobuf.MapToStruct(violations[i].Details), violation.Details)
 		}
 	})
+
+	t.Run("should include warnings alongside errors", func(t *testing.T) {
+		warning := validation.ConstraintViolation{
+			Path:     ".baz",
+			Severity: validation.SeverityWarning,
+			Message:  "test warning",
+		}
+
+		status := validation.ViolationsToStatus(append(violations, warning))
+		assert.Equal(t, codes.InvalidArgument, status.Code())
+		fromStatus := validation.ViolationsFromStatus(status)
+		require.Len(t, fromStatus, 3)
+		assert.Equal(t, validation.SeverityWarning, fromStatus[2].Severity)
+	})
+
+	t.Run("should return an OK status if there are no error-level violations", func(t *testing.T) {
+		status := validation.ViolationsToStatus([]validation.ConstraintViolation{
+			{Path: ".baz", Severity: validation.SeverityWarning, Message: "test warning"},
+		})
+
+		assert.Equal(t, codes.OK, status.Code())
+		assert.NoError(t, status.Err())
+	})
 }
 
 func TestViolationsFromStatus(t *testing.T) {
@@ -653,3 +1008,37 @@ func BenchmarkValidateHappy(b *testing.B) {
 		b.Error("expected no constraint violations")
 	}
 }
+
+func BenchmarkValidateHappyCompiled(b *testing.B) {
+	ts := testSubject1{}
+	ts.Bool = true
+	ts.Text = "Hello, GitHub!"
+	ts.TextMap = map[string]string{"hello longer key": "world"}
+	ts.Int = 999
+	ts.Int2 = &ts.Int
+	ts.Ints = []int{1}
+	ts.Float = math.Pi
+	ts.Nested = &testSubject2{Text: "Hello, GitHub!"}
+	ts.Adults = 2
+	ts.Children = 4
+	ts.Times = []time.Time{
+		time.Date(1800, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	cc := validation.Compile(reflect.TypeOf(ts), ts.Constraints())
+
+	ctx := validation.NewContext(ts)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var violations []validation.ConstraintViolation
+	for i := 0; i < b.N; i++ {
+		violations = validation.ValidateContext(ctx, cc)
+	}
+
+	b.Log(violations)
+	if len(violations) != 0 {
+		b.Error("expected no constraint violations")
+	}
+}
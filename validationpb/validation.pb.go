@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.34.1
+// 	protoc-gen-go v1.36.11
 // 	protoc        (unknown)
 // source: validationpb/validation.proto
 
@@ -12,6 +12,7 @@ import (
 	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -69,26 +70,131 @@ func (PathKind) EnumDescriptor() ([]byte, []int) {
 	return file_validationpb_validation_proto_rawDescGZIP(), []int{0}
 }
 
+// PathSegmentKind is a ProtoBuf representation of the PathSegmentKind type, enumerating the
+// different kinds of step that can be taken to reach a value.
+type PathSegmentKind int32
+
+const (
+	PathSegmentKind_PATH_SEGMENT_KIND_FIELD PathSegmentKind = 0
+	PathSegmentKind_PATH_SEGMENT_KIND_INDEX PathSegmentKind = 1
+	PathSegmentKind_PATH_SEGMENT_KIND_KEY   PathSegmentKind = 2
+)
+
+// Enum value maps for PathSegmentKind.
+var (
+	PathSegmentKind_name = map[int32]string{
+		0: "PATH_SEGMENT_KIND_FIELD",
+		1: "PATH_SEGMENT_KIND_INDEX",
+		2: "PATH_SEGMENT_KIND_KEY",
+	}
+	PathSegmentKind_value = map[string]int32{
+		"PATH_SEGMENT_KIND_FIELD": 0,
+		"PATH_SEGMENT_KIND_INDEX": 1,
+		"PATH_SEGMENT_KIND_KEY":   2,
+	}
+)
+
+func (x PathSegmentKind) Enum() *PathSegmentKind {
+	p := new(PathSegmentKind)
+	*p = x
+	return p
+}
+
+func (x PathSegmentKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PathSegmentKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_validationpb_validation_proto_enumTypes[1].Descriptor()
+}
+
+func (PathSegmentKind) Type() protoreflect.EnumType {
+	return &file_validationpb_validation_proto_enumTypes[1]
+}
+
+func (x PathSegmentKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PathSegmentKind.Descriptor instead.
+func (PathSegmentKind) EnumDescriptor() ([]byte, []int) {
+	return file_validationpb_validation_proto_rawDescGZIP(), []int{1}
+}
+
+// Severity is a ProtoBuf representation of the Severity type, enumerating how serious a constraint
+// violation is.
+type Severity int32
+
+const (
+	Severity_SEVERITY_ERROR   Severity = 0
+	Severity_SEVERITY_WARNING Severity = 1
+	Severity_SEVERITY_INFO    Severity = 2
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "SEVERITY_ERROR",
+		1: "SEVERITY_WARNING",
+		2: "SEVERITY_INFO",
+	}
+	Severity_value = map[string]int32{
+		"SEVERITY_ERROR":   0,
+		"SEVERITY_WARNING": 1,
+		"SEVERITY_INFO":    2,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_validationpb_validation_proto_enumTypes[2].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_validationpb_validation_proto_enumTypes[2]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_validationpb_validation_proto_rawDescGZIP(), []int{2}
+}
+
 // ConstraintViolation is a ProtoBuf representation of the the ConstraintViolation type, intended to
 // allow ConstraintViolations to be used with gRPC more easily.
 type ConstraintViolation struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	PathKind      PathKind               `protobuf:"varint,2,opt,name=path_kind,json=pathKind,proto3,enum=seeruk.validation.PathKind" json:"path_kind,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Details       *structpb.Struct       `protobuf:"bytes,4,opt,name=details,proto3" json:"details,omitempty"`
+	Segments      []*PathSegment         `protobuf:"bytes,5,rep,name=segments,proto3" json:"segments,omitempty"`
+	Severity      Severity               `protobuf:"varint,6,opt,name=severity,proto3,enum=seeruk.validation.Severity" json:"severity,omitempty"`
+	Code          string                 `protobuf:"bytes,7,opt,name=code,proto3" json:"code,omitempty"`
+	Template      string                 `protobuf:"bytes,8,opt,name=template,proto3" json:"template,omitempty"`
+	Group         string                 `protobuf:"bytes,9,opt,name=group,proto3" json:"group,omitempty"`
+	Custom        bool                   `protobuf:"varint,10,opt,name=custom,proto3" json:"custom,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Path     string           `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	PathKind PathKind         `protobuf:"varint,2,opt,name=path_kind,json=pathKind,proto3,enum=seeruk.validation.PathKind" json:"path_kind,omitempty"`
-	Message  string           `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Details  *structpb.Struct `protobuf:"bytes,4,opt,name=details,proto3" json:"details,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ConstraintViolation) Reset() {
 	*x = ConstraintViolation{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_validationpb_validation_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_validationpb_validation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ConstraintViolation) String() string {
@@ -99,7 +205,7 @@ func (*ConstraintViolation) ProtoMessage() {}
 
 func (x *ConstraintViolation) ProtoReflect() protoreflect.Message {
 	mi := &file_validationpb_validation_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -142,22 +248,61 @@ func (x *ConstraintViolation) GetDetails() *structpb.Struct {
 	return nil
 }
 
+func (x *ConstraintViolation) GetSegments() []*PathSegment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *ConstraintViolation) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_SEVERITY_ERROR
+}
+
+func (x *ConstraintViolation) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ConstraintViolation) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *ConstraintViolation) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ConstraintViolation) GetCustom() bool {
+	if x != nil {
+		return x.Custom
+	}
+	return false
+}
+
 // ConstraintViolations is a ProtoBuf representation of multiple ConstraintViolation values.
 type ConstraintViolations struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Violations    []*ConstraintViolation `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Violations []*ConstraintViolation `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ConstraintViolations) Reset() {
 	*x = ConstraintViolations{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_validationpb_validation_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_validationpb_validation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ConstraintViolations) String() string {
@@ -168,7 +313,7 @@ func (*ConstraintViolations) ProtoMessage() {}
 
 func (x *ConstraintViolations) ProtoReflect() protoreflect.Message {
 	mi := &file_validationpb_validation_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -190,69 +335,160 @@ func (x *ConstraintViolations) GetViolations() []*ConstraintViolation {
 	return nil
 }
 
-var File_validationpb_validation_proto protoreflect.FileDescriptor
+// PathSegment is a ProtoBuf representation of the PathSegment type, describing a single step in the
+// path to a value that has violated a constraint.
+type PathSegment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          PathSegmentKind        `protobuf:"varint,1,opt,name=kind,proto3,enum=seeruk.validation.PathSegmentKind" json:"kind,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Field         string                 `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	Index         int64                  `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	Key           *structpb.Value        `protobuf:"bytes,5,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathSegment) Reset() {
+	*x = PathSegment{}
+	mi := &file_validationpb_validation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var file_validationpb_validation_proto_rawDesc = []byte{
-	0x0a, 0x1d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x2f, 0x76,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x11, 0x73, 0x65, 0x65, 0x72, 0x75, 0x6b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0xb0, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x56,
-	0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x38, 0x0a, 0x09,
-	0x70, 0x61, 0x74, 0x68, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x1b, 0x2e, 0x73, 0x65, 0x65, 0x72, 0x75, 0x6b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x08, 0x70, 0x61,
-	0x74, 0x68, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x31, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61,
-	0x69, 0x6c, 0x73, 0x22, 0x5e, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
-	0x74, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x46, 0x0a, 0x0a, 0x76,
-	0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x26, 0x2e, 0x73, 0x65, 0x65, 0x72, 0x75, 0x6b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x56, 0x69,
-	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x2a, 0x1e, 0x0a, 0x08, 0x50, 0x61, 0x74, 0x68, 0x4b, 0x69, 0x6e, 0x64, 0x12,
-	0x09, 0x0a, 0x05, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x45,
-	0x59, 0x10, 0x01, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x73, 0x65, 0x65, 0x72, 0x75, 0x6b, 0x2f, 0x67, 0x6f, 0x2d, 0x76, 0x61, 0x6c, 0x69,
-	0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x70, 0x62, 0x3b, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *PathSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
+func (*PathSegment) ProtoMessage() {}
+
+func (x *PathSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_validationpb_validation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathSegment.ProtoReflect.Descriptor instead.
+func (*PathSegment) Descriptor() ([]byte, []int) {
+	return file_validationpb_validation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PathSegment) GetKind() PathSegmentKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PathSegmentKind_PATH_SEGMENT_KIND_FIELD
+}
+
+func (x *PathSegment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PathSegment) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *PathSegment) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PathSegment) GetKey() *structpb.Value {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+var File_validationpb_validation_proto protoreflect.FileDescriptor
+
+const file_validationpb_validation_proto_rawDesc = "" +
+	"\n" +
+	"\x1dvalidationpb/validation.proto\x12\x11seeruk.validation\x1a\x1cgoogle/protobuf/struct.proto\"\x83\x03\n" +
+	"\x13ConstraintViolation\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x128\n" +
+	"\tpath_kind\x18\x02 \x01(\x0e2\x1b.seeruk.validation.PathKindR\bpathKind\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x121\n" +
+	"\adetails\x18\x04 \x01(\v2\x17.google.protobuf.StructR\adetails\x12:\n" +
+	"\bsegments\x18\x05 \x03(\v2\x1e.seeruk.validation.PathSegmentR\bsegments\x127\n" +
+	"\bseverity\x18\x06 \x01(\x0e2\x1b.seeruk.validation.SeverityR\bseverity\x12\x12\n" +
+	"\x04code\x18\a \x01(\tR\x04code\x12\x1a\n" +
+	"\btemplate\x18\b \x01(\tR\btemplate\x12\x14\n" +
+	"\x05group\x18\t \x01(\tR\x05group\x12\x16\n" +
+	"\x06custom\x18\n" +
+	" \x01(\bR\x06custom\"^\n" +
+	"\x14ConstraintViolations\x12F\n" +
+	"\n" +
+	"violations\x18\x01 \x03(\v2&.seeruk.validation.ConstraintViolationR\n" +
+	"violations\"\xaf\x01\n" +
+	"\vPathSegment\x126\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\".seeruk.validation.PathSegmentKindR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05field\x18\x03 \x01(\tR\x05field\x12\x14\n" +
+	"\x05index\x18\x04 \x01(\x03R\x05index\x12(\n" +
+	"\x03key\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\x03key*\x1e\n" +
+	"\bPathKind\x12\t\n" +
+	"\x05VALUE\x10\x00\x12\a\n" +
+	"\x03KEY\x10\x01*f\n" +
+	"\x0fPathSegmentKind\x12\x1b\n" +
+	"\x17PATH_SEGMENT_KIND_FIELD\x10\x00\x12\x1b\n" +
+	"\x17PATH_SEGMENT_KIND_INDEX\x10\x01\x12\x19\n" +
+	"\x15PATH_SEGMENT_KIND_KEY\x10\x02*G\n" +
+	"\bSeverity\x12\x12\n" +
+	"\x0eSEVERITY_ERROR\x10\x00\x12\x14\n" +
+	"\x10SEVERITY_WARNING\x10\x01\x12\x11\n" +
+	"\rSEVERITY_INFO\x10\x02B;Z9github.com/seeruk/go-validation/validationpb;validationpbb\x06proto3"
+
 var (
 	file_validationpb_validation_proto_rawDescOnce sync.Once
-	file_validationpb_validation_proto_rawDescData = file_validationpb_validation_proto_rawDesc
+	file_validationpb_validation_proto_rawDescData []byte
 )
 
 func file_validationpb_validation_proto_rawDescGZIP() []byte {
 	file_validationpb_validation_proto_rawDescOnce.Do(func() {
-		file_validationpb_validation_proto_rawDescData = protoimpl.X.CompressGZIP(file_validationpb_validation_proto_rawDescData)
+		file_validationpb_validation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_validationpb_validation_proto_rawDesc), len(file_validationpb_validation_proto_rawDesc)))
 	})
 	return file_validationpb_validation_proto_rawDescData
 }
 
-var file_validationpb_validation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_validationpb_validation_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_validationpb_validation_proto_goTypes = []interface{}{
+var file_validationpb_validation_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_validationpb_validation_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_validationpb_validation_proto_goTypes = []any{
 	(PathKind)(0),                // 0: seeruk.validation.PathKind
-	(*ConstraintViolation)(nil),  // 1: seeruk.validation.ConstraintViolation
-	(*ConstraintViolations)(nil), // 2: seeruk.validation.ConstraintViolations
-	(*structpb.Struct)(nil),      // 3: google.protobuf.Struct
+	(PathSegmentKind)(0),         // 1: seeruk.validation.PathSegmentKind
+	(Severity)(0),                // 2: seeruk.validation.Severity
+	(*ConstraintViolation)(nil),  // 3: seeruk.validation.ConstraintViolation
+	(*ConstraintViolations)(nil), // 4: seeruk.validation.ConstraintViolations
+	(*PathSegment)(nil),          // 5: seeruk.validation.PathSegment
+	(*structpb.Struct)(nil),      // 6: google.protobuf.Struct
+	(*structpb.Value)(nil),       // 7: google.protobuf.Value
 }
 var file_validationpb_validation_proto_depIdxs = []int32{
 	0, // 0: seeruk.validation.ConstraintViolation.path_kind:type_name -> seeruk.validation.PathKind
-	3, // 1: seeruk.validation.ConstraintViolation.details:type_name -> google.protobuf.Struct
-	1, // 2: seeruk.validation.ConstraintViolations.violations:type_name -> seeruk.validation.ConstraintViolation
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	6, // 1: seeruk.validation.ConstraintViolation.details:type_name -> google.protobuf.Struct
+	5, // 2: seeruk.validation.ConstraintViolation.segments:type_name -> seeruk.validation.PathSegment
+	2, // 3: seeruk.validation.ConstraintViolation.severity:type_name -> seeruk.validation.Severity
+	3, // 4: seeruk.validation.ConstraintViolations.violations:type_name -> seeruk.validation.ConstraintViolation
+	1, // 5: seeruk.validation.PathSegment.kind:type_name -> seeruk.validation.PathSegmentKind
+	7, // 6: seeruk.validation.PathSegment.key:type_name -> google.protobuf.Value
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_validationpb_validation_proto_init() }
@@ -260,39 +496,13 @@ func file_validationpb_validation_proto_init() {
 	if File_validationpb_validation_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_validationpb_validation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConstraintViolation); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_validationpb_validation_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConstraintViolations); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_validationpb_validation_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   2,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_validationpb_validation_proto_rawDesc), len(file_validationpb_validation_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -302,7 +512,6 @@ func file_validationpb_validation_proto_init() {
 		MessageInfos:      file_validationpb_validation_proto_msgTypes,
 	}.Build()
 	File_validationpb_validation_proto = out.File
-	file_validationpb_validation_proto_rawDesc = nil
 	file_validationpb_validation_proto_goTypes = nil
 	file_validationpb_validation_proto_depIdxs = nil
 }